@@ -0,0 +1,141 @@
+package orm
+
+import "github.com/tinywasm/fmt"
+
+// AggregateFunc identifies an aggregate function applied to a column.
+type AggregateFunc int
+
+const (
+	AggregateNone AggregateFunc = iota
+	AggregateCount
+	AggregateSum
+	AggregateAvg
+	AggregateMin
+	AggregateMax
+)
+
+var aggregateNames = []string{"", "COUNT", "SUM", "AVG", "MIN", "MAX"}
+
+// String returns the SQL name of the function ("SUM", "AVG", ...).
+func (a AggregateFunc) String() string {
+	if int(a) >= 0 && int(a) < len(aggregateNames) {
+		return aggregateNames[a]
+	}
+	return ""
+}
+
+// Sum returns the sum of column over the matching rows, or 0 when no row
+// matches.
+func (qb *QB) Sum(column string) (float64, error) {
+	return qb.aggregate(AggregateSum, column)
+}
+
+// Avg returns the average of column over the matching rows.
+func (qb *QB) Avg(column string) (float64, error) {
+	return qb.aggregate(AggregateAvg, column)
+}
+
+// Min returns the smallest value of column over the matching rows.
+func (qb *QB) Min(column string) (float64, error) {
+	return qb.aggregate(AggregateMin, column)
+}
+
+// Max returns the largest value of column over the matching rows.
+func (qb *QB) Max(column string) (float64, error) {
+	return qb.aggregate(AggregateMax, column)
+}
+
+// aggregateQuery builds an ActionAggregate query for fn(column).
+func (qb *QB) aggregateQuery(fn AggregateFunc, column string) (Query, error) {
+	if err := qb.check(ActionAggregate); err != nil {
+		return Query{}, err
+	}
+	if err := validateColumns(qb.model, []string{column}); err != nil {
+		return Query{}, err
+	}
+	q := qb.query(ActionAggregate)
+	q.Aggregate = fn
+	q.Columns = []string{column}
+	return q, nil
+}
+
+func (qb *QB) aggregate(fn AggregateFunc, column string) (float64, error) {
+	q, err := qb.aggregateQuery(fn, column)
+	if err != nil {
+		return 0, err
+	}
+	q.GroupBy, q.Having, q.OrderBy, q.Limit, q.Offset = nil, nil, nil, 0, 0
+	plan, err := qb.db.compiler.Compile(q, qb.model)
+	if err != nil {
+		return 0, err
+	}
+
+	// SUM/AVG/MIN/MAX yield NULL when no row matches; scan into any so
+	// executors that reject NULL into float64 still succeed.
+	var raw any
+	if err := qb.db.queryRowPlan(plan).Scan(&raw); err != nil {
+		return 0, err
+	}
+	return aggregateValue(raw)
+}
+
+// GroupAggregate applies fn to column for each group of the single GroupBy
+// column and calls onRow with the group key and the aggregated value.
+// Each result row is scanned as (groupKey, value).
+func (qb *QB) GroupAggregate(fn AggregateFunc, column string, onRow func(key any, value float64)) error {
+	q, err := qb.aggregateQuery(fn, column)
+	if err != nil {
+		return err
+	}
+	if len(q.GroupBy) != 1 {
+		return fmt.Err(ErrValidation, "group by", "one column")
+	}
+	plan, err := qb.db.compiler.Compile(q, qb.model)
+	if err != nil {
+		return err
+	}
+
+	return qb.db.eachRow(plan, func(rows Rows) error {
+		var key, raw any
+		if err := rows.Scan(&key, &raw); err != nil {
+			return err
+		}
+		v, err := aggregateValue(raw)
+		if err != nil {
+			return err
+		}
+		onRow(key, v)
+		return nil
+	})
+}
+
+// aggregateValue converts a scanned aggregate result to float64. NULL (no
+// matching rows) becomes 0; numeric text, as some drivers return for
+// DECIMAL results, is parsed.
+func aggregateValue(raw any) (float64, error) {
+	switch v := raw.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case []byte:
+		return fmt.Convert(string(v)).Float64()
+	case string:
+		return fmt.Convert(v).Float64()
+	}
+	return 0, fmt.Err(ErrValidation, "aggregate", "value", "unsupported")
+}
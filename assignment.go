@@ -0,0 +1,106 @@
+package orm
+
+import (
+	"slices"
+
+	"github.com/tinywasm/fmt"
+)
+
+// AssignKind identifies how an Assignment computes the new column value.
+type AssignKind int
+
+const (
+	AssignValue  AssignKind = iota // column = value
+	AssignIncr                     // column = column + value
+	AssignDecr                     // column = column - value
+	AssignColumn                   // column = other column (Value holds its name)
+	AssignNow                      // column = DB clock (Value holds the resolved int64)
+)
+
+// Assignment is one SET expression of an update.
+// It is a sealed value type constructed via Set, Incr, Decr, SetColumn and SetNow.
+type Assignment struct {
+	column string
+	kind   AssignKind
+	value  any
+}
+
+func (a Assignment) Column() string   { return a.column }
+func (a Assignment) Kind() AssignKind { return a.kind }
+func (a Assignment) Value() any       { return a.value }
+
+// Set assigns a literal value: column = value.
+func Set(column string, value any) Assignment {
+	return Assignment{column: column, kind: AssignValue, value: value}
+}
+
+// Incr atomically increments a column: column = column + by.
+func Incr(column string, by any) Assignment {
+	return Assignment{column: column, kind: AssignIncr, value: by}
+}
+
+// Decr atomically decrements a column: column = column - by.
+func Decr(column string, by any) Assignment {
+	return Assignment{column: column, kind: AssignDecr, value: by}
+}
+
+// SetColumn copies another column of the same row: column = source.
+func SetColumn(column, source string) Assignment {
+	return Assignment{column: column, kind: AssignColumn, value: source}
+}
+
+// SetNow assigns the current time of the DB clock (see DB.SetClock).
+// The value is resolved when the update runs.
+func SetNow(column string) Assignment {
+	return Assignment{column: column, kind: AssignNow}
+}
+
+// UpdateSet updates the rows matching the query conditions with the given
+// assignments, without reading them first:
+//
+//	db.Query(&Post{}).Where("id").Eq(id).UpdateSet(orm.Incr("views", 1), orm.SetNow("updated_at"))
+//
+// At least one Where condition is required. Assignment columns are validated
+// against the model schema. Use UpdateAll to get the affected-row count.
+func (qb *QB) UpdateSet(assignments ...Assignment) error {
+	_, err := qb.UpdateAll(assignments...)
+	return err
+}
+
+// updateQuery validates assignments and builds the ActionUpdate query,
+// adding SetNow for the updated_at column (see UpdatedAtStamper) and
+// resolving SetNow against the DB clock.
+func (qb *QB) updateQuery(assignments []Assignment) (Query, error) {
+	if err := qb.check(ActionUpdate); err != nil {
+		return Query{}, err
+	}
+	if len(assignments) == 0 {
+		return Query{}, fmt.Err(ErrValidation, "no assignments")
+	}
+	if s, ok := qb.model.(UpdatedAtStamper); ok {
+		col := s.UpdatedAtColumn()
+		if !slices.ContainsFunc(assignments, func(a Assignment) bool { return a.column == col }) {
+			assignments = append(slices.Clip(assignments), SetNow(col))
+		}
+	}
+	resolved := make([]Assignment, len(assignments))
+	for i, a := range assignments {
+		columns := []string{a.column}
+		if a.kind == AssignColumn {
+			source, _ := a.value.(string)
+			columns = append(columns, source)
+		}
+		if err := validateColumns(qb.model, columns); err != nil {
+			return Query{}, err
+		}
+		if a.kind == AssignNow {
+			a.value = qb.db.now()
+		}
+		resolved[i] = a
+	}
+
+	q := qb.query(ActionUpdate)
+	q.Columns = nil
+	q.Assignments = resolved
+	return q, nil
+}
@@ -0,0 +1,72 @@
+package orm
+
+import "github.com/tinywasm/fmt"
+
+// AllowFullTable lets UpdateAll and DeleteAll run without any Where
+// condition. Without it, a builder with no conditions is rejected to prevent
+// accidental full-table UPDATE and DELETE statements.
+func (qb *QB) AllowFullTable() *QB {
+	qb.allowFullTable = true
+	return qb
+}
+
+// requireConditions enforces the at-least-one-condition guarantee of writes.
+func (qb *QB) requireConditions() error {
+	if len(qb.conds) == 0 && !qb.allowFullTable {
+		return fmt.Err(ErrValidation, "no conditions")
+	}
+	return nil
+}
+
+// UpdateAll applies assignments to every row matching the builder's
+// conditions, honouring OrderBy and Limit. It returns the number of affected
+// rows, or -1 when the executor does not report it (see ResultExecutor).
+func (qb *QB) UpdateAll(assignments ...Assignment) (int64, error) {
+	if err := qb.requireConditions(); err != nil {
+		return 0, err
+	}
+	q, err := qb.updateQuery(assignments)
+	if err != nil {
+		return 0, err
+	}
+	return qb.execAffected(q)
+}
+
+// DeleteAll removes every row matching the builder's conditions, honouring
+// OrderBy and Limit. Soft-deletable models are stamped instead of removed.
+// It returns the number of affected rows, or -1 when the executor does not
+// report it.
+func (qb *QB) DeleteAll() (int64, error) {
+	if err := qb.requireConditions(); err != nil {
+		return 0, err
+	}
+	if err := qb.check(ActionDelete); err != nil {
+		return 0, err
+	}
+	q := qb.query(ActionDelete)
+	q.Columns = nil
+	if col := softDeleteColumn(qb.model); col != "" {
+		q = qb.db.softDelete(q, qb.model, col)
+	}
+	return qb.execAffected(q)
+}
+
+// execAffected compiles and runs a write query and reports RowsAffected.
+func (qb *QB) execAffected(q Query) (int64, error) {
+	plan, err := qb.db.compiler.Compile(q, qb.model)
+	if err != nil {
+		return 0, err
+	}
+	res, err := qb.db.execResultPlan(plan)
+	if err != nil {
+		return 0, err
+	}
+	if res == nil {
+		return -1, nil
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return -1, nil
+	}
+	return n, nil
+}
@@ -0,0 +1,19 @@
+package orm
+
+import "time"
+
+// SetClock replaces the time source used by SetNow, soft delete and the
+// created_at / updated_at stamps.
+// fn returns Unix nanoseconds, matching the int64 timestamps stored by models.
+// Tests pass a fixed function to freeze time; nil restores time.Now.
+func (db *DB) SetClock(fn func() int64) {
+	db.clock = fn
+}
+
+// now returns the current time from the clock set with SetClock.
+func (db *DB) now() int64 {
+	if db.clock != nil {
+		return db.clock()
+	}
+	return time.Now().UnixNano()
+}
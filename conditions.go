@@ -1,24 +1,76 @@
 package orm
 
+// Operator is the comparison applied by a Condition.
+// The set is closed: Operators() lists every value so compilers can reject
+// the ones their engine does not support.
+type Operator string
+
+const (
+	OpEq        Operator = "="
+	OpNeq       Operator = "!="
+	OpGt        Operator = ">"
+	OpGte       Operator = ">="
+	OpLt        Operator = "<"
+	OpLte       Operator = "<="
+	OpLike      Operator = "LIKE"
+	OpNotLike   Operator = "NOT LIKE"
+	OpILike     Operator = "ILIKE" // case-insensitive LIKE
+	OpIn        Operator = "IN"
+	OpNotIn     Operator = "NOT IN"
+	OpIsNull    Operator = "IS NULL"     // no value
+	OpIsNotNull Operator = "IS NOT NULL" // no value
+	OpBetween   Operator = "BETWEEN"     // value is []any{lo, hi}
+	OpExists    Operator = "EXISTS"      // value is a Subquery, no field
+	OpNotExists Operator = "NOT EXISTS"  // value is a Subquery, no field
+	OpGroup     Operator = "GROUP"       // members in Children()
+	OpNot       Operator = "NOT"         // single member in Children()
+)
+
+// Operators returns every Operator a Condition may carry.
+func Operators() []Operator {
+	return []Operator{
+		OpEq, OpNeq, OpGt, OpGte, OpLt, OpLte,
+		OpLike, OpNotLike, OpILike,
+		OpIn, OpNotIn,
+		OpIsNull, OpIsNotNull, OpBetween,
+		OpExists, OpNotExists,
+		OpGroup, OpNot,
+	}
+}
+
 // Condition represents a filter for a query.
 // It is a sealed value type constructed via helper functions.
+//
+// Conditions form a tree: a group (OpGroup, OpNot) has no field or value and
+// carries its members in Children(). Within a list of siblings, Logic() joins
+// each condition to the one before it; the first condition's Logic is ignored.
+// Compilers wrap every group in parentheses.
 type Condition struct {
-	field    string
-	operator string
-	value    any
-	logic    string
+	field     string
+	operator  Operator
+	value     any
+	logic     string
+	aggregate AggregateFunc
+	children  []Condition
 }
 
-func (c Condition) Field() string    { return c.field }
-func (c Condition) Operator() string { return c.operator }
-func (c Condition) Value() any       { return c.value }
-func (c Condition) Logic() string    { return c.logic }
+func (c Condition) Field() string      { return c.field }
+func (c Condition) Operator() Operator { return c.operator }
+func (c Condition) Value() any         { return c.value }
+func (c Condition) Logic() string      { return c.logic }
+
+// Children returns the members of an OpGroup or OpNot condition.
+func (c Condition) Children() []Condition { return c.children }
+
+// Aggregate returns the function applied to Field before comparing,
+// or AggregateNone. Only meaningful in Query.Having.
+func (c Condition) Aggregate() AggregateFunc { return c.aggregate }
 
 // Eq creates a condition for checking equality.
 func Eq(field string, value any) Condition {
 	return Condition{
 		field:    field,
-		operator: "=",
+		operator: OpEq,
 		value:    value,
 		logic:    "AND",
 	}
@@ -28,7 +80,7 @@ func Eq(field string, value any) Condition {
 func Neq(field string, value any) Condition {
 	return Condition{
 		field:    field,
-		operator: "!=",
+		operator: OpNeq,
 		value:    value,
 		logic:    "AND",
 	}
@@ -38,7 +90,7 @@ func Neq(field string, value any) Condition {
 func Gt(field string, value any) Condition {
 	return Condition{
 		field:    field,
-		operator: ">",
+		operator: OpGt,
 		value:    value,
 		logic:    "AND",
 	}
@@ -48,7 +100,7 @@ func Gt(field string, value any) Condition {
 func Gte(field string, value any) Condition {
 	return Condition{
 		field:    field,
-		operator: ">=",
+		operator: OpGte,
 		value:    value,
 		logic:    "AND",
 	}
@@ -58,7 +110,7 @@ func Gte(field string, value any) Condition {
 func Lt(field string, value any) Condition {
 	return Condition{
 		field:    field,
-		operator: "<",
+		operator: OpLt,
 		value:    value,
 		logic:    "AND",
 	}
@@ -68,7 +120,7 @@ func Lt(field string, value any) Condition {
 func Lte(field string, value any) Condition {
 	return Condition{
 		field:    field,
-		operator: "<=",
+		operator: OpLte,
 		value:    value,
 		logic:    "AND",
 	}
@@ -78,24 +130,149 @@ func Lte(field string, value any) Condition {
 func Like(field string, value any) Condition {
 	return Condition{
 		field:    field,
-		operator: "LIKE",
+		operator: OpLike,
 		value:    value,
 		logic:    "AND",
 	}
 }
 
 // In creates a condition for checking if a value is in a list of values.
+// value may also be a *QB, which is stored as a Subquery.
 func In(field string, value any) Condition {
 	return Condition{
 		field:    field,
-		operator: "IN",
+		operator: OpIn,
+		value:    subqueryValue(value),
+		logic:    "AND",
+	}
+}
+
+// NotIn creates a condition for checking if a value is not in a list of values.
+// value may also be a *QB, which is stored as a Subquery.
+func NotIn(field string, value any) Condition {
+	return Condition{
+		field:    field,
+		operator: OpNotIn,
+		value:    subqueryValue(value),
+		logic:    "AND",
+	}
+}
+
+// NotLike creates a condition for checking if a value does not match a pattern.
+func NotLike(field string, value any) Condition {
+	return Condition{
+		field:    field,
+		operator: OpNotLike,
 		value:    value,
 		logic:    "AND",
 	}
 }
 
+// ILike creates a case-insensitive pattern match condition.
+func ILike(field string, value any) Condition {
+	return Condition{
+		field:    field,
+		operator: OpILike,
+		value:    value,
+		logic:    "AND",
+	}
+}
+
+// IsNull creates a condition for checking if a value is NULL.
+func IsNull(field string) Condition {
+	return Condition{
+		field:    field,
+		operator: OpIsNull,
+		logic:    "AND",
+	}
+}
+
+// IsNotNull creates a condition for checking if a value is not NULL.
+func IsNotNull(field string) Condition {
+	return Condition{
+		field:    field,
+		operator: OpIsNotNull,
+		logic:    "AND",
+	}
+}
+
+// Between creates a condition for checking if a value lies in [lo, hi].
+// The value is stored as []any{lo, hi}.
+func Between(field string, lo, hi any) Condition {
+	return Condition{
+		field:    field,
+		operator: OpBetween,
+		value:    []any{lo, hi},
+		logic:    "AND",
+	}
+}
+
 // Or creates a condition with OR logic.
 func Or(c Condition) Condition {
 	c.logic = "OR"
 	return c
 }
+
+// Aggregated applies fn to the field of c, e.g. Aggregated(AggregateSum, Gt("total", 100))
+// compares SUM(total) > 100. Intended for QB.Having.
+func Aggregated(fn AggregateFunc, c Condition) Condition {
+	c.aggregate = fn
+	return c
+}
+
+// CountEq creates a HAVING condition COUNT(field) = value.
+func CountEq(field string, value any) Condition {
+	return Aggregated(AggregateCount, Eq(field, value))
+}
+
+// CountGt creates a HAVING condition COUNT(field) > value.
+func CountGt(field string, value any) Condition {
+	return Aggregated(AggregateCount, Gt(field, value))
+}
+
+// CountGte creates a HAVING condition COUNT(field) >= value.
+func CountGte(field string, value any) Condition {
+	return Aggregated(AggregateCount, Gte(field, value))
+}
+
+// CountLt creates a HAVING condition COUNT(field) < value.
+func CountLt(field string, value any) Condition {
+	return Aggregated(AggregateCount, Lt(field, value))
+}
+
+// CountLte creates a HAVING condition COUNT(field) <= value.
+func CountLte(field string, value any) Condition {
+	return Aggregated(AggregateCount, Lte(field, value))
+}
+
+// AllOf groups conditions joined with AND: (c1 AND c2 AND ...).
+func AllOf(conds ...Condition) Condition {
+	return group(conds, "AND")
+}
+
+// AnyOf groups conditions joined with OR: (c1 OR c2 OR ...).
+func AnyOf(conds ...Condition) Condition {
+	return group(conds, "OR")
+}
+
+// Not negates a condition: NOT (c).
+func Not(c Condition) Condition {
+	return Condition{
+		operator: OpNot,
+		logic:    "AND",
+		children: []Condition{c},
+	}
+}
+
+func group(conds []Condition, logic string) Condition {
+	children := make([]Condition, len(conds))
+	for i, c := range conds {
+		c.logic = logic
+		children[i] = c
+	}
+	return Condition{
+		operator: OpGroup,
+		logic:    "AND",
+		children: children,
+	}
+}
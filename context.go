@@ -0,0 +1,120 @@
+package orm
+
+import "context"
+
+// ContextExecutor is an optional Executor extension for engines that accept
+// a context.Context. When implemented, DB forwards the context bound via
+// WithContext so cancellation and deadlines reach the engine.
+type ContextExecutor interface {
+	Executor
+	ExecContext(ctx context.Context, query string, args ...any) error
+	QueryRowContext(ctx context.Context, query string, args ...any) Scanner
+	QueryContext(ctx context.Context, query string, args ...any) (Rows, error)
+}
+
+// ContextResultExecutor is an optional ResultExecutor extension that accepts
+// a context.Context.
+type ContextResultExecutor interface {
+	ResultExecutor
+	ExecResultContext(ctx context.Context, query string, args ...any) (Result, error)
+}
+
+// TxContextExecutor is an optional TxExecutor extension that starts a
+// transaction bound to a context.
+type TxContextExecutor interface {
+	TxExecutor
+	BeginTxContext(ctx context.Context) (TxBoundExecutor, error)
+}
+
+// WithContext returns a shallow copy of db whose operations carry ctx.
+// Executors implementing ContextExecutor receive ctx directly; for the rest,
+// ctx is checked before each operation and between rows in ReadAll.
+func (db *DB) WithContext(ctx context.Context) *DB {
+	c := *db
+	c.ctx = ctx
+	return &c
+}
+
+// Context returns the context bound to db, or context.Background() if none.
+func (db *DB) Context() context.Context {
+	if db.ctx == nil {
+		return context.Background()
+	}
+	return db.ctx
+}
+
+// ctxErr reports whether the bound context is already done.
+func (db *DB) ctxErr() error {
+	if db.ctx == nil {
+		return nil
+	}
+	return db.ctx.Err()
+}
+
+// execPlan runs a write plan through the executor.
+func (db *DB) execPlan(plan Plan) error {
+	if db.ctx != nil {
+		if ce, ok := db.exec.(ContextExecutor); ok {
+			return ce.ExecContext(db.ctx, plan.Query, plan.Args...)
+		}
+		if err := db.ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return db.exec.Exec(plan.Query, plan.Args...)
+}
+
+// execResultPlan runs a write plan and returns its Result when the executor
+// implements ResultExecutor. Otherwise the Result is nil. With a bound
+// context, an executor implementing ContextExecutor but not
+// ContextResultExecutor runs through ExecContext and reports no Result:
+// cancellation takes precedence over the Result.
+func (db *DB) execResultPlan(plan Plan) (Result, error) {
+	re, ok := db.exec.(ResultExecutor)
+	if !ok {
+		return nil, db.execPlan(plan)
+	}
+	if db.ctx != nil {
+		if ce, ok := re.(ContextResultExecutor); ok {
+			return ce.ExecResultContext(db.ctx, plan.Query, plan.Args...)
+		}
+		if _, ok := re.(ContextExecutor); ok {
+			return nil, db.execPlan(plan)
+		}
+		if err := db.ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return re.ExecResult(plan.Query, plan.Args...)
+}
+
+// queryRowPlan runs a single-row read plan through the executor.
+func (db *DB) queryRowPlan(plan Plan) Scanner {
+	if db.ctx != nil {
+		if ce, ok := db.exec.(ContextExecutor); ok {
+			return ce.QueryRowContext(db.ctx, plan.Query, plan.Args...)
+		}
+		if err := db.ctx.Err(); err != nil {
+			return errScanner{err}
+		}
+	}
+	return db.exec.QueryRow(plan.Query, plan.Args...)
+}
+
+// queryPlan runs a multi-row read plan through the executor.
+func (db *DB) queryPlan(plan Plan) (Rows, error) {
+	if db.ctx != nil {
+		if ce, ok := db.exec.(ContextExecutor); ok {
+			return ce.QueryContext(db.ctx, plan.Query, plan.Args...)
+		}
+		if err := db.ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return db.exec.Query(plan.Query, plan.Args...)
+}
+
+// errScanner is a Scanner that always fails with err.
+type errScanner struct{ err error }
+
+func (s errScanner) Scan(dest ...any) error { return s.err }
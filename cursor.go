@@ -0,0 +1,235 @@
+package orm
+
+import (
+	"encoding/base64"
+	"math"
+	"slices"
+
+	"github.com/tinywasm/fmt"
+)
+
+// Cursor is an opaque keyset position: the values of the ordering columns
+// (plus the PK tiebreaker) of one row. The zero Cursor is the start of the
+// result set.
+// It is a sealed value type constructed via QB.Cursor(), NewCursor() or DecodeCursor().
+type Cursor struct {
+	values []any
+}
+
+// NewCursor builds a Cursor from raw key values, in keyset order.
+func NewCursor(values ...any) Cursor { return Cursor{values: values} }
+
+func (c Cursor) Values() []any { return c.values }
+
+// IsZero reports whether c holds no position.
+func (c Cursor) IsZero() bool { return len(c.values) == 0 }
+
+// Encode returns c as a URL-safe token suitable for next_cursor fields.
+// The zero Cursor encodes to "".
+// Integers, floats, strings, bools, []byte and nil keep their kind through
+// DecodeCursor; other values are encoded by their string form.
+func (c Cursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+	parts := make([]string, len(c.values))
+	for i, v := range c.values {
+		parts[i] = encodeCursorValue(v)
+	}
+	raw := fmt.Convert(parts).Join(",").String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode.
+// An empty token yields the zero Cursor.
+func DecodeCursor(token string) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, fmt.Err(ErrValidation, "cursor", "invalid")
+	}
+	parts := fmt.Convert(string(raw)).Split(",")
+	values := make([]any, len(parts))
+	for i, p := range parts {
+		if values[i], err = decodeCursorValue(p); err != nil {
+			return Cursor{}, err
+		}
+	}
+	return Cursor{values: values}, nil
+}
+
+// encodeCursorValue encodes v as a one-letter kind prefix followed by its payload.
+func encodeCursorValue(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "n"
+	case bool:
+		if x {
+			return "b1"
+		}
+		return "b0"
+	case int, int8, int16, int32, int64:
+		return "i" + fmt.Convert(x).String()
+	case uint, uint8, uint16, uint32, uint64:
+		return "u" + fmt.Convert(x).String()
+	case float32:
+		return "f" + fmt.Convert(math.Float64bits(float64(x))).String()
+	case float64:
+		return "f" + fmt.Convert(math.Float64bits(x)).String()
+	case []byte:
+		return "x" + base64.RawURLEncoding.EncodeToString(x)
+	case string:
+		return "s" + base64.RawURLEncoding.EncodeToString([]byte(x))
+	default:
+		return "s" + base64.RawURLEncoding.EncodeToString([]byte(fmt.Convert(x).String()))
+	}
+}
+
+// decodeCursorValue reverses encodeCursorValue.
+func decodeCursorValue(p string) (any, error) {
+	if p == "" {
+		return nil, fmt.Err(ErrValidation, "cursor", "invalid")
+	}
+	kind, payload := p[0], p[1:]
+	switch kind {
+	case 'n':
+		return nil, nil
+	case 'b':
+		return payload == "1", nil
+	case 'i':
+		if n, err := fmt.Convert(payload).Int64(); err == nil {
+			return n, nil
+		}
+	case 'u':
+		if n, err := fmt.Convert(payload).Uint64(); err == nil {
+			return n, nil
+		}
+	case 'f':
+		if n, err := fmt.Convert(payload).Uint64(); err == nil {
+			return math.Float64frombits(n), nil
+		}
+	case 'x':
+		if b, err := base64.RawURLEncoding.DecodeString(payload); err == nil {
+			return b, nil
+		}
+	case 's':
+		if b, err := base64.RawURLEncoding.DecodeString(payload); err == nil {
+			return string(b), nil
+		}
+	}
+	return nil, fmt.Err(ErrValidation, "cursor", "invalid")
+}
+
+// keysetOrder returns the OrderBy list extended with the PK as tiebreaker,
+// using the direction of the last OrderBy column (ASC when there is none).
+func (qb *QB) keysetOrder() ([]Order, error) {
+	i, err := pkIndex(qb.model)
+	if err != nil {
+		return nil, err
+	}
+	pk := qb.model.Schema()[i].Name
+	dir := "ASC"
+	for _, o := range qb.orderBy {
+		if o.column == pk {
+			return qb.orderBy, nil
+		}
+		dir = o.dir
+	}
+	return append(slices.Clip(qb.orderBy), Order{column: pk, dir: dir}), nil
+}
+
+// Cursor returns the keyset position of m, a row read by this query.
+// Encode it and pass it back through After or Before to fetch the next page.
+func (qb *QB) Cursor(m Model) (Cursor, error) {
+	order, err := qb.keysetOrder()
+	if err != nil {
+		return Cursor{}, err
+	}
+	schema := m.Schema()
+	all := fmt.ReadValues(schema, m.Pointers())
+	values := make([]any, len(order))
+	for i, o := range order {
+		j := slices.IndexFunc(schema, func(f fmt.Field) bool { return f.Name == o.column })
+		if j < 0 {
+			return Cursor{}, fmt.Err(ErrValidation, "unknown column", o.column)
+		}
+		values[i] = all[j]
+	}
+	return Cursor{values: values}, nil
+}
+
+// After restricts the query to rows that sort after c, expanding
+// (sort_col, pk) > (?, ?) into portable conditions. Call it after OrderBy;
+// the PK is appended to the ordering as tiebreaker. DESC columns compare
+// with <. The zero Cursor only applies the keyset ordering, so the first
+// page and the following ones sort identically.
+//
+//	qb.OrderBy("created").Desc().After(cur).Limit(20)
+func (qb *QB) After(c Cursor) *QB {
+	return qb.keyset(c, false)
+}
+
+// Before restricts the query to rows that sort before c. The ordering is
+// reversed so that Limit keeps the rows nearest to c; rows are therefore
+// returned in reverse order and callers reverse them for display.
+func (qb *QB) Before(c Cursor) *QB {
+	return qb.keyset(c, true)
+}
+
+// keyset applies the keyset ordering of qb and, unless c is zero, the
+// condition selecting the rows past c.
+func (qb *QB) keyset(c Cursor, before bool) *QB {
+	order, err := qb.keysetOrder()
+	if err == nil && !c.IsZero() && len(c.values) != len(order) {
+		err = fmt.Err(ErrValidation, "cursor", "columns", "mismatch")
+	}
+	if err != nil {
+		qb.err = err
+		return qb
+	}
+
+	if !c.IsZero() {
+		qb.addCondition(keysetCondition(order, c.values, before))
+	}
+	if before {
+		reversed := make([]Order, len(order))
+		for i, o := range order {
+			if o.dir == "DESC" {
+				o.dir = "ASC"
+			} else {
+				o.dir = "DESC"
+			}
+			reversed[i] = o
+		}
+		order = reversed
+	}
+	qb.orderBy = order
+	return qb
+}
+
+// keysetCondition expands (k1, k2, ...) > (v1, v2, ...) into
+// k1 > v1 OR (k1 = v1 AND k2 > v2) OR ..., comparing each key with < when
+// its direction (flipped by before) is descending.
+func keysetCondition(order []Order, values []any, before bool) Condition {
+	terms := make([]Condition, len(order))
+	for i, o := range order {
+		cmp := Lt
+		if (o.dir == "ASC") != before {
+			cmp = Gt
+		}
+		conds := make([]Condition, 0, i+1)
+		for j := range i {
+			conds = append(conds, Eq(order[j].column, values[j]))
+		}
+		terms[i] = cmp(o.column, values[i])
+		if i > 0 {
+			terms[i] = AllOf(append(conds, terms[i])...)
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return AnyOf(terms...)
+}
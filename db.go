@@ -1,80 +1,304 @@
 package orm
 
-import "github.com/tinywasm/fmt"
+import (
+	"context"
+	"slices"
+
+	"github.com/tinywasm/fmt"
+)
 
 // DB represents a database connection.
 // Consumers instantiate it via New().
 type DB struct {
-	exec     Executor
-	compiler Compiler
+	exec      Executor
+	root      Executor // executor passed to New; exec is replaced inside Tx
+	compiler  Compiler
+	ctx       context.Context // nil = no context bound; see WithContext
+	batchSize int
+	inTx      bool         // set on the DB passed to Tx callbacks
+	clock     func() int64 // nil = time.Now; see SetClock
 }
 
+// DefaultBatchSize is the number of rows CreateMany sends per statement
+// unless changed with SetBatchSize.
+const DefaultBatchSize = 100
+
 // New creates a new DB instance.
 func New(exec Executor, compiler Compiler) *DB {
 	return &DB{
-		exec:     exec,
-		compiler: compiler,
+		exec:      exec,
+		root:      exec,
+		compiler:  compiler,
+		batchSize: DefaultBatchSize,
 	}
 }
 
+// SetBatchSize sets the maximum number of rows CreateMany sends per statement.
+// Values below 1 restore DefaultBatchSize.
+func (db *DB) SetBatchSize(n int) {
+	if n < 1 {
+		n = DefaultBatchSize
+	}
+	db.batchSize = n
+}
+
 // Create inserts a new model into the database.
 func (db *DB) Create(m Model) error {
 	if err := validate(ActionCreate, m); err != nil {
 		return err
 	}
-	schema := m.Schema()
+	db.stampCreate(m)
 	ptrs := m.Pointers()
+	columns, values, autoInc := insertValues(m.Schema(), ptrs)
+	q := Query{
+		Action:  ActionCreate,
+		Table:   m.TableName(),
+		Columns: columns,
+		Values:  values,
+	}
+	plan, err := db.compiler.Compile(q, m)
+	if err != nil {
+		return err
+	}
+	res, err := db.execResultPlan(plan)
+	if err != nil {
+		return err
+	}
+	// Write the key assigned by the DB back into the model.
+	// Engines that cannot report it leave the field untouched.
+	if autoInc >= 0 && res != nil {
+		if id, err := res.LastInsertId(); err == nil {
+			setInt(ptrs[autoInc], id)
+		}
+	}
+	return nil
+}
+
+// insertValues returns the columns and values to insert for a model.
+// Autoincrement PK fields with a zero value are skipped so the DB assigns them;
+// autoInc is the schema index of the skipped field, or -1.
+func insertValues(schema []fmt.Field, ptrs []any) (columns []string, values []any, autoInc int) {
 	allValues := fmt.ReadValues(schema, ptrs)
-	var columns []string
-	var values []any
+	autoInc = -1
 	for i, f := range schema {
-		// Skip autoincrement PK fields with zero value — let the DB assign them.
-		if f.PK && f.AutoInc {
-			if v, ok := allValues[i].(int); ok && v == 0 {
-				continue
-			}
+		if f.PK && f.AutoInc && isZeroInt(allValues[i]) {
+			autoInc = i
+			continue
 		}
 		columns = append(columns, f.Name)
 		values = append(values, allValues[i])
 	}
+	return columns, values, autoInc
+}
+
+// CreateMany inserts several models of the same table using multi-row
+// statements of at most SetBatchSize rows each.
+// All models must share the same table and insert the same columns.
+// Generated autoincrement keys are not written back.
+func (db *DB) CreateMany(models ...Model) error {
+	if len(models) == 0 {
+		return nil
+	}
+	first := models[0]
+	var columns []string
+	for i, m := range models {
+		if err := validate(ActionCreate, m); err != nil {
+			return err
+		}
+		if m.TableName() != first.TableName() {
+			return fmt.Err(ErrValidation, "table mismatch", m.TableName())
+		}
+		cols, _, _ := insertValues(m.Schema(), m.Pointers())
+		if i == 0 {
+			columns = cols
+		} else if !slices.Equal(columns, cols) {
+			return fmt.Err(ErrValidation, "columns mismatch", m.TableName())
+		}
+	}
+
+	// Stamp only once every model is valid, so a rejected call leaves the
+	// caller's models untouched. Stamped columns are never autoincrement,
+	// so the inserted columns stay the same.
+	batch := make([][]any, 0, len(models))
+	for _, m := range models {
+		db.stampCreate(m)
+		_, values, _ := insertValues(m.Schema(), m.Pointers())
+		batch = append(batch, values)
+	}
+
+	size := db.batchSize
+	for start := 0; start < len(batch); start += size {
+		end := min(start+size, len(batch))
+		q := Query{
+			Action:  ActionCreateMany,
+			Table:   first.TableName(),
+			Columns: columns,
+			Batch:   batch[start:end],
+		}
+		plan, err := db.compiler.Compile(q, first)
+		if err != nil {
+			return err
+		}
+		if err := db.execPlan(plan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Upsert inserts m or, when a row with the same conflict target already exists,
+// updates that row with the remaining inserted columns.
+// conflictColumns defaults to the schema PK fields, or the Unique fields when
+// the schema has no PK or the autoincrement PK is still zero (it is left out
+// of the INSERT, so it could never conflict).
+// At least one inserted column must remain outside the conflict target to be
+// updated; otherwise Upsert fails with ErrValidation.
+func (db *DB) Upsert(m Model, conflictColumns ...string) error {
+	if err := validate(ActionUpsert, m); err != nil {
+		return err
+	}
+	if err := validateColumns(m, conflictColumns); err != nil {
+		return err
+	}
+	schema := m.Schema()
+	db.stampCreate(m)
+	db.stampUpdate(m)
+	columns, values, autoInc := insertValues(schema, m.Pointers())
+	if len(conflictColumns) == 0 {
+		conflictColumns = defaultConflictColumns(schema, autoInc >= 0)
+		if len(conflictColumns) == 0 {
+			return fmt.Err(ErrValidation, "no conflict columns")
+		}
+	}
+	createdAt := createdAtColumn(m)
+	var updateColumns []string
+	for _, c := range columns {
+		// The existing row keeps its creation time.
+		if !slices.Contains(conflictColumns, c) && c != createdAt {
+			updateColumns = append(updateColumns, c)
+		}
+	}
+	if len(updateColumns) == 0 {
+		return fmt.Err(ErrValidation, "no update columns")
+	}
 	q := Query{
-		Action:  ActionCreate,
-		Table:   m.TableName(),
-		Columns: columns,
-		Values:  values,
+		Action:          ActionUpsert,
+		Table:           m.TableName(),
+		Columns:         columns,
+		Values:          values,
+		ConflictColumns: conflictColumns,
+		UpdateColumns:   updateColumns,
 	}
 	plan, err := db.compiler.Compile(q, m)
 	if err != nil {
 		return err
 	}
-	return db.exec.Exec(plan.Query, plan.Args...)
+	return db.execPlan(plan)
+}
+
+// defaultConflictColumns returns the PK columns, or the Unique columns
+// when the schema declares no PK or skipPK is set.
+func defaultConflictColumns(schema []fmt.Field, skipPK bool) []string {
+	var pk, unique []string
+	for _, f := range schema {
+		if f.PK {
+			if skipPK {
+				continue
+			}
+			pk = append(pk, f.Name)
+		} else if f.Unique {
+			unique = append(unique, f.Name)
+		}
+	}
+	if len(pk) > 0 {
+		return pk
+	}
+	return unique
 }
 
 // Update modifies an existing row. At least one Condition is required.
 // Providing zero conditions is a compile-time error — there is no variadic
 // fallback — preventing accidental full-table UPDATE statements.
+// The created_at column (see CreatedAtStamper) is not written.
 func (db *DB) Update(m Model, cond Condition, rest ...Condition) error {
 	if err := validate(ActionUpdate, m); err != nil {
 		return err
 	}
 	conds := append([]Condition{cond}, rest...)
+	if err := subqueryErr(conds); err != nil {
+		return err
+	}
+	db.stampUpdate(m)
 	schema := m.Schema()
-	columns := make([]string, len(schema))
+	all := fmt.ReadValues(schema, m.Pointers())
+	createdAt := createdAtColumn(m)
+	columns := make([]string, 0, len(schema))
+	values := make([]any, 0, len(schema))
 	for i, f := range schema {
-		columns[i] = f.Name
+		// The row keeps its creation time.
+		if f.Name == createdAt {
+			continue
+		}
+		columns = append(columns, f.Name)
+		values = append(values, all[i])
+	}
+	q := Query{
+		Action:     ActionUpdate,
+		Table:      m.TableName(),
+		Columns:    columns,
+		Values:     values,
+		Conditions: conds,
+	}
+	plan, err := db.compiler.Compile(q, m)
+	if err != nil {
+		return err
+	}
+	return db.execPlan(plan)
+}
+
+// UpdateColumns modifies an existing row writing only the named columns.
+// Columns are validated against m.Schema() before the query is compiled.
+// Like Update, at least one Condition is required.
+func (db *DB) UpdateColumns(m Model, columns []string, cond Condition, rest ...Condition) error {
+	if err := validate(ActionUpdate, m); err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return fmt.Err(ErrValidation, "no columns")
+	}
+	if err := validateColumns(m, columns); err != nil {
+		return err
+	}
+	conds := append([]Condition{cond}, rest...)
+	if err := subqueryErr(conds); err != nil {
+		return err
+	}
+	if col := db.stampUpdate(m); col != "" && !slices.Contains(columns, col) {
+		columns = append(slices.Clip(columns), col)
+	}
+	schema := m.Schema()
+	all := fmt.ReadValues(schema, m.Pointers())
+	values := make([]any, len(columns))
+	for i, c := range columns {
+		for j, f := range schema {
+			if f.Name == c {
+				values[i] = all[j]
+				break
+			}
+		}
 	}
 	q := Query{
 		Action:     ActionUpdate,
 		Table:      m.TableName(),
 		Columns:    columns,
-		Values:     fmt.ReadValues(schema, m.Pointers()),
+		Values:     values,
 		Conditions: conds,
 	}
 	plan, err := db.compiler.Compile(q, m)
 	if err != nil {
 		return err
 	}
-	return db.exec.Exec(plan.Query, plan.Args...)
+	return db.execPlan(plan)
 }
 
 // emptyModel is a private zero-value type used only for CreateDatabase.
@@ -97,7 +321,7 @@ func (db *DB) CreateTable(m Model) error {
 	if err != nil {
 		return err
 	}
-	return db.exec.Exec(plan.Query, plan.Args...)
+	return db.execPlan(plan)
 }
 
 // DropTable drops the table for the given model.
@@ -113,7 +337,7 @@ func (db *DB) DropTable(m Model) error {
 	if err != nil {
 		return err
 	}
-	return db.exec.Exec(plan.Query, plan.Args...)
+	return db.execPlan(plan)
 }
 
 // CreateDatabase creates a new database.
@@ -130,27 +354,35 @@ func (db *DB) CreateDatabase(name string) error {
 	if err != nil {
 		return err
 	}
-	return db.exec.Exec(plan.Query, plan.Args...)
+	return db.execPlan(plan)
 }
 
 // Delete deletes a model from the database.
 // At least one Condition is required. Providing zero conditions is a compile-time
 // error, preventing accidental full-table DELETE statements.
+// Soft-deletable models (see SoftDeleter) are stamped instead of removed.
 func (db *DB) Delete(m Model, cond Condition, rest ...Condition) error {
 	if err := validate(ActionDelete, m); err != nil {
 		return err
 	}
 	conds := append([]Condition{cond}, rest...)
+	if err := subqueryErr(conds); err != nil {
+		return err
+	}
 	q := Query{
 		Action:     ActionDelete,
 		Table:      m.TableName(),
 		Conditions: conds,
 	}
+	if col := softDeleteColumn(m); col != "" {
+		q.Conditions = withFilter(q.Conditions, Eq(col, 0))
+		q = db.softDelete(q, m, col)
+	}
 	plan, err := db.compiler.Compile(q, m)
 	if err != nil {
 		return err
 	}
-	return db.exec.Exec(plan.Query, plan.Args...)
+	return db.execPlan(plan)
 }
 
 // Query creates a new QB instance.
@@ -0,0 +1,55 @@
+package orm
+
+// Distinct marks the query as SELECT DISTINCT.
+func (qb *QB) Distinct() *QB {
+	qb.distinct = true
+	return qb
+}
+
+// DistinctOn keeps one row per distinct combination of columns
+// (DISTINCT ON in PostgreSQL). Columns missing from the model schema fail
+// with ErrValidation at the terminal call.
+func (qb *QB) DistinctOn(columns ...string) *QB {
+	if err := validateColumns(qb.model, columns); err != nil {
+		qb.err = err
+	}
+	qb.distinctOn = append(qb.distinctOn, columns...)
+	return qb
+}
+
+// Pluck streams the distinct values of a single column, without scanning
+// full models. Conditions, OrderBy, Limit and Offset apply as usual; row
+// locks are dropped since DISTINCT reads cannot lock.
+//
+//	var countries []string
+//	err := db.Query(&User{}).Pluck("country", func(v any) {
+//		countries = append(countries, v.(string))
+//	})
+func (qb *QB) Pluck(column string, onValue func(any)) error {
+	if qb.lock != LockNone {
+		// DISTINCT reads cannot lock rows; Pluck runs unlocked like Count.
+		qb = qb.clone()
+		qb.lock, qb.lockWait = LockNone, LockWaitDefault
+	}
+	if err := qb.check(ActionReadAll); err != nil {
+		return err
+	}
+	if err := validateColumns(qb.model, []string{column}); err != nil {
+		return err
+	}
+	q := qb.query(ActionReadAll)
+	q.Columns, q.Distinct = []string{column}, true
+	plan, err := qb.db.compiler.Compile(q, qb.model)
+	if err != nil {
+		return err
+	}
+
+	return qb.db.eachRow(plan, func(rows Rows) error {
+		var v any
+		if err := rows.Scan(&v); err != nil {
+			return err
+		}
+		onValue(v)
+		return nil
+	})
+}
@@ -3,6 +3,8 @@ package orm
 import "github.com/tinywasm/fmt"
 
 // ErrNotFound is returned when ReadOne() finds no matching row.
+// Executors may return it directly from Scan, or implement NotFoundChecker
+// to have their native error translated.
 var ErrNotFound = fmt.Err("record", "not", "found")
 
 // ErrValidation is returned when validate() finds a mismatch.
@@ -13,3 +15,10 @@ var ErrEmptyTable = fmt.Err("name", "table", "empty")
 
 // ErrNoTxSupport is returned by DB.Tx() when the executor does not implement TxExecutor.
 var ErrNoTxSupport = fmt.Err("transaction", "not", "supported")
+
+// ErrNoPK is returned by primary-key helpers when the model schema has no PK field.
+var ErrNoPK = fmt.Err("primary", "key", "missing")
+
+// ErrLockOutsideTx is returned when a locking read (ForUpdate, ForShare) runs
+// outside DB.Tx, where the lock would be released immediately.
+var ErrLockOutsideTx = fmt.Err("lock", "outside", "transaction")
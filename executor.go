@@ -21,3 +21,39 @@ type Rows interface {
 	Close() error
 	Err() error
 }
+
+// Result reports the outcome of a write operation.
+// It is satisfied by database/sql.Result.
+type Result interface {
+	LastInsertId() (int64, error)
+	RowsAffected() (int64, error)
+}
+
+// ResultExecutor is an optional Executor extension for engines that report
+// the outcome of a write, such as the key assigned to an auto-increment column.
+type ResultExecutor interface {
+	Executor
+	ExecResult(query string, args ...any) (Result, error)
+}
+
+// NotFoundChecker is an optional Executor extension that recognises the
+// engine-specific "no rows" error (e.g. sql.ErrNoRows) so ReadOne can
+// return ErrNotFound regardless of backend.
+type NotFoundChecker interface {
+	IsNotFound(err error) bool
+}
+
+// notFound maps an executor's "no rows" error to ErrNotFound, asking the
+// transaction-bound executor first and then the one passed to New.
+// Other errors are returned unchanged.
+func (db *DB) notFound(err error) error {
+	if err == nil || err == ErrNotFound {
+		return err
+	}
+	for _, exec := range []Executor{db.exec, db.root} {
+		if c, ok := exec.(NotFoundChecker); ok && c.IsNotFound(err) {
+			return ErrNotFound
+		}
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package orm
+
+import (
+	"iter"
+
+	"github.com/tinywasm/fmt"
+)
+
+// errStopIter signals that the consumer of Iter stopped the loop early.
+var errStopIter = fmt.Err("iteration", "stopped")
+
+// Iter executes the query and yields one fresh Model per row, built by new().
+// Breaking out of the range loop stops the iteration and closes the Rows.
+// Errors are yielded once with a nil Model, after which iteration ends.
+//
+//	for m, err := range qb.Iter(func() orm.Model { return &User{} }) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (qb *QB) Iter(new func() Model) iter.Seq2[Model, error] {
+	return func(yield func(Model, error) bool) {
+		plan, idx, err := qb.readAllPlan()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		err = qb.db.eachRow(plan, func(rows Rows) error {
+			m := new()
+			if err := rows.Scan(scanPointers(m, idx)...); err != nil {
+				return err
+			}
+			if !yield(m, nil) {
+				return errStopIter
+			}
+			return nil
+		})
+		if err != nil && err != errStopIter {
+			yield(nil, err)
+		}
+	}
+}
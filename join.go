@@ -0,0 +1,150 @@
+package orm
+
+import (
+	"slices"
+
+	"github.com/tinywasm/fmt"
+)
+
+// JoinKind identifies the type of join.
+type JoinKind int
+
+const (
+	JoinInner JoinKind = iota
+	JoinLeft
+)
+
+// Join describes a table joined into a query.
+// It is a sealed value type constructed via QB.Join() and QB.LeftJoin().
+type Join struct {
+	kind  JoinKind
+	model Model
+	on    Condition
+}
+
+func (j Join) Kind() JoinKind { return j.kind }
+func (j Join) Table() string  { return j.model.TableName() }
+func (j Join) Model() Model   { return j.model }
+func (j Join) On() Condition  { return j.on }
+
+// Column references a column by name where a Condition expects a value,
+// e.g. Eq("role.user_id", Col("user.id")) in a join condition.
+// Compilers render it as an identifier instead of a bound argument.
+type Column string
+
+// Col creates a column reference.
+func Col(name string) Column { return Column(name) }
+
+// Qualify returns the table-qualified column name "table.column".
+func Qualify(table, column string) string {
+	return table + "." + column
+}
+
+// Join adds an inner join with other on the given condition.
+func (qb *QB) Join(other Model, on Condition) *QB {
+	qb.joins = append(qb.joins, Join{kind: JoinInner, model: other, on: on})
+	return qb
+}
+
+// LeftJoin adds a left outer join with other on the given condition.
+func (qb *QB) LeftJoin(other Model, on Condition) *QB {
+	qb.joins = append(qb.joins, Join{kind: JoinLeft, model: other, on: on})
+	return qb
+}
+
+// qualifiedColumns returns the schema columns of m selected by idx
+// (all when idx is nil), qualified with its table name.
+func qualifiedColumns(m Model, idx []int) []string {
+	schema := m.Schema()
+	table := m.TableName()
+	if idx == nil {
+		cols := make([]string, len(schema))
+		for i, f := range schema {
+			cols[i] = Qualify(table, f.Name)
+		}
+		return cols
+	}
+	cols := make([]string, len(idx))
+	for i, j := range idx {
+		cols[i] = Qualify(table, schema[j].Name)
+	}
+	return cols
+}
+
+// ReadAllJoined executes a joined query and scans each row into several models.
+// new returns one fresh Model per table: the query model first, then one per
+// join in the order they were added. Columns are read in the same order.
+// LeftJoin columns are scanned through nullable temporaries; when a row has
+// no match (every column NULL) the model is passed to onRow as nil.
+func (qb *QB) ReadAllJoined(new func() []Model, onRow func([]Model)) error {
+	if err := qb.check(ActionReadAll); err != nil {
+		return err
+	}
+	for _, j := range qb.joins {
+		if err := validate(ActionReadAll, j.model); err != nil {
+			return err
+		}
+	}
+	idx, err := qb.scanIndexes()
+	if err != nil {
+		return err
+	}
+	q := qb.query(ActionReadAll)
+	q.Columns = qualifiedColumns(qb.model, idx)
+	for _, j := range qb.joins {
+		q.Columns = append(q.Columns, qualifiedColumns(j.model, nil)...)
+	}
+	plan, err := qb.db.compiler.Compile(q, qb.model)
+	if err != nil {
+		return err
+	}
+
+	return qb.db.eachRow(plan, func(rows Rows) error {
+		ms := new()
+		if len(ms) != len(qb.joins)+1 {
+			return fmt.Err(ErrValidation, "joined models mismatch")
+		}
+		ptrs := scanPointers(ms[0], idx)
+		var nullable [][]any // per join: scan targets of LeftJoin models, nil otherwise
+		for i, m := range ms[1:] {
+			if qb.joins[i].kind != JoinLeft {
+				ptrs = append(ptrs, m.Pointers()...)
+				nullable = append(nullable, nil)
+				continue
+			}
+			raw := make([]any, len(m.Schema()))
+			for k := range raw {
+				ptrs = append(ptrs, &raw[k])
+			}
+			nullable = append(nullable, raw)
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		for i, raw := range nullable {
+			if raw == nil {
+				continue
+			}
+			if err := assignJoined(ms[i+1], raw); err != nil {
+				return err
+			}
+			if !slices.ContainsFunc(raw, func(v any) bool { return v != nil }) {
+				ms[i+1] = nil
+			}
+		}
+		onRow(ms)
+		return nil
+	})
+}
+
+// assignJoined writes the non-NULL values scanned for a LeftJoin model
+// through its Pointers(); NULL columns keep their zero value.
+func assignJoined(m Model, raw []any) error {
+	schema := m.Schema()
+	for k, ptr := range m.Pointers() {
+		if raw[k] != nil && !assignScanned(ptr, raw[k]) {
+			return fmt.Err(ErrValidation, "scan", schema[k].Name)
+		}
+	}
+	return nil
+}
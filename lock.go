@@ -0,0 +1,93 @@
+package orm
+
+import "github.com/tinywasm/fmt"
+
+// LockMode identifies a row locking clause applied to a read.
+type LockMode int
+
+const (
+	LockNone LockMode = iota
+	LockForUpdate
+	LockForShare
+)
+
+var lockModeNames = []string{"", "FOR UPDATE", "FOR SHARE"}
+
+// String returns the SQL clause of the mode ("FOR UPDATE", "FOR SHARE").
+func (l LockMode) String() string {
+	if int(l) >= 0 && int(l) < len(lockModeNames) {
+		return lockModeNames[l]
+	}
+	return ""
+}
+
+// LockWait controls what a locking read does when rows are already locked.
+type LockWait int
+
+const (
+	LockWaitDefault LockWait = iota // block until the lock is released
+	LockSkipLocked
+	LockNoWait
+)
+
+var lockWaitNames = []string{"", "SKIP LOCKED", "NOWAIT"}
+
+// String returns the SQL modifier ("SKIP LOCKED", "NOWAIT").
+func (w LockWait) String() string {
+	if int(w) >= 0 && int(w) < len(lockWaitNames) {
+		return lockWaitNames[w]
+	}
+	return ""
+}
+
+// ForUpdate locks the rows read for update until the transaction ends.
+// It must run on the DB passed to db.Tx; otherwise the terminal call
+// returns ErrLockOutsideTx. Only ReadOne and ReadAll compile the lock;
+// counts, aggregates, Pluck, subqueries and bulk writes on the same builder
+// run without it, inside or outside a transaction.
+func (qb *QB) ForUpdate() *QB {
+	qb.lock = LockForUpdate
+	return qb
+}
+
+// ForShare locks the rows read against concurrent updates until the
+// transaction ends. Same transaction requirement as ForUpdate.
+func (qb *QB) ForShare() *QB {
+	qb.lock = LockForShare
+	return qb
+}
+
+// SkipLocked makes a locking read skip rows locked by other transactions,
+// so concurrent workers each pick different rows.
+func (qb *QB) SkipLocked() *QB {
+	qb.lockWait = LockSkipLocked
+	return qb
+}
+
+// NoWait makes a locking read fail instead of waiting for locked rows.
+func (qb *QB) NoWait() *QB {
+	qb.lockWait = LockNoWait
+	return qb
+}
+
+// checkLock rejects locking reads outside a transaction, where the lock
+// would be released as soon as the statement ends. Actions that do not
+// carry the lock (see locks) only get the wait modifier check.
+func (qb *QB) checkLock(action Action) error {
+	if qb.lock == LockNone {
+		if qb.lockWait != LockWaitDefault {
+			return fmt.Err(ErrValidation, "lock", "mode", "missing")
+		}
+		return nil
+	}
+	if locks(action) && !qb.db.inTx {
+		return ErrLockOutsideTx
+	}
+	return nil
+}
+
+// locks reports whether action compiles the builder's row lock: only row
+// reads do; counts, aggregates and bulk writes drop it.
+func locks(action Action) bool {
+	return action == ActionReadOne || action == ActionReadAll
+}
@@ -22,6 +22,9 @@ type FieldInfo struct {
 	Unique     bool
 	NotNull    bool
 	AutoInc    bool
+	SoftDelete bool // db:"softdelete": int64 column stamped by DB.Delete instead of removing the row
+	CreatedAt  bool // db:"created_at": int64 column filled from the DB clock on insert
+	UpdatedAt  bool // db:"updated_at": int64 column filled from the DB clock on insert and update
 	Ref        string
 	RefColumn  string
 	IsPK       bool
@@ -146,6 +149,7 @@ func (o *Ormc) ParseStruct(structName string, goFile string) (StructInfo, error)
 		FormOnly:          formOnly,
 	}
 
+	stampFound := map[string]bool{} // softdelete, created_at, updated_at: at most one field each
 	pkFound := false
 	for _, field := range targetStruct.Fields.List {
 		if len(field.Names) == 0 {
@@ -246,7 +250,7 @@ func (o *Ormc) ParseStruct(structName string, goFile string) (StructInfo, error)
 		colName := Convert(fieldName).SnakeLow().String()
 		isID, isPK := IDorPrimaryKey(tableName, fieldName)
 
-		var pk, unique, notNull, autoInc bool
+		var pk, unique, notNull, autoInc, softDelete, createdAt, updatedAt bool
 		var ref, refCol string
 
 		fieldIsPK := false
@@ -275,6 +279,22 @@ func (o *Ormc) ParseStruct(structName string, goFile string) (StructInfo, error)
 						return StructInfo{}, Err("autoincrement not allowed on FieldText")
 					}
 					autoInc = true
+				case p == "softdelete", p == "created_at", p == "updated_at":
+					if fieldType != FieldInt {
+						return StructInfo{}, Err(p + " requires an int field")
+					}
+					// The DB clock yields Unix nanoseconds; narrower ints
+					// (int is 32 bits on some targets) would truncate them.
+					if typeStr != "int64" && typeStr != "uint64" {
+						return StructInfo{}, Err(p + " requires an int64 or uint64 field")
+					}
+					if stampFound[p] {
+						return StructInfo{}, Err(p + " declared more than once")
+					}
+					stampFound[p] = true
+					softDelete = softDelete || p == "softdelete"
+					createdAt = createdAt || p == "created_at"
+					updatedAt = updatedAt || p == "updated_at"
 				case HasPrefix(p, "ref="):
 					refVal := Convert(p).TrimPrefix("ref=").String()
 					refParts := Convert(refVal).Split(":")
@@ -294,6 +314,9 @@ func (o *Ormc) ParseStruct(structName string, goFile string) (StructInfo, error)
 			Unique:     unique,
 			NotNull:    notNull,
 			AutoInc:    autoInc,
+			SoftDelete: softDelete,
+			CreatedAt:  createdAt,
+			UpdatedAt:  updatedAt,
 			Ref:        ref,
 			RefColumn:  refCol,
 			IsPK:       fieldIsPK,
@@ -338,6 +361,9 @@ func (o *Ormc) GenerateForFile(infos []StructInfo, sourceFile string) error {
 	}
 
 	buf.Write("import (\n")
+	if hasModel {
+		buf.Write("\t\"iter\"\n\n")
+	}
 	buf.Write("\t\"github.com/tinywasm/fmt\"\n")
 	if hasModel {
 		buf.Write("\t\"github.com/tinywasm/orm\"\n")
@@ -408,6 +434,18 @@ func (o *Ormc) GenerateForFile(infos []StructInfo, sourceFile string) error {
 		buf.Write("}\n\n")
 
 		if !info.FormOnly {
+			for _, f := range info.Fields {
+				if f.SoftDelete {
+					buf.Write(Sprintf("func (m *%s) SoftDeleteColumn() string { return \"%s\" }\n\n", info.Name, f.ColumnName))
+				}
+				if f.CreatedAt {
+					buf.Write(Sprintf("func (m *%s) CreatedAtColumn() string { return \"%s\" }\n\n", info.Name, f.ColumnName))
+				}
+				if f.UpdatedAt {
+					buf.Write(Sprintf("func (m *%s) UpdatedAtColumn() string { return \"%s\" }\n\n", info.Name, f.ColumnName))
+				}
+			}
+
 			// Metadata Descriptors
 			buf.Write(Sprintf("var %s_ = struct {\n", info.Name))
 			buf.Write("\tTableName string\n")
@@ -439,6 +477,29 @@ func (o *Ormc) GenerateForFile(infos []StructInfo, sourceFile string) error {
 			buf.Write("\treturn results, err\n")
 			buf.Write("}\n\n")
 
+			buf.Write(Sprintf("func Paginate%s(qb *orm.QB, page, perPage int) ([]*%s, orm.PageInfo, error) {\n", info.Name, info.Name))
+			buf.Write(Sprintf("\tvar results []*%s\n", info.Name))
+			buf.Write("\tinfo, err := qb.Paginate(page, perPage,\n")
+			buf.Write(Sprintf("\t\tfunc() orm.Model { return &%s{} },\n", info.Name))
+			buf.Write(Sprintf("\t\tfunc(m orm.Model) { results = append(results, m.(*%s)) },\n", info.Name))
+			buf.Write("\t)\n")
+			buf.Write("\treturn results, info, err\n")
+			buf.Write("}\n\n")
+
+			buf.Write(Sprintf("func Iter%s(qb *orm.QB) iter.Seq2[*%s, error] {\n", info.Name, info.Name))
+			buf.Write(Sprintf("\treturn func(yield func(*%s, error) bool) {\n", info.Name))
+			buf.Write(Sprintf("\t\tfor m, err := range qb.Iter(func() orm.Model { return &%s{} }) {\n", info.Name))
+			buf.Write("\t\t\tif err != nil {\n")
+			buf.Write("\t\t\t\tyield(nil, err)\n")
+			buf.Write("\t\t\t\treturn\n")
+			buf.Write("\t\t\t}\n")
+			buf.Write(Sprintf("\t\t\tif !yield(m.(*%s), nil) {\n", info.Name))
+			buf.Write("\t\t\t\treturn\n")
+			buf.Write("\t\t\t}\n")
+			buf.Write("\t\t}\n")
+			buf.Write("\t}\n")
+			buf.Write("}\n\n")
+
 			for _, rel := range info.Relations {
 				buf.Write(Sprintf(
 					"// ReadAll%sByParentID retrieves all %s records for a given parent ID.\n"+
@@ -448,11 +509,26 @@ func (o *Ormc) GenerateForFile(infos []StructInfo, sourceFile string) error {
 						"}\n\n",
 					rel.ChildStruct,
 					rel.ChildStruct,
-					info.TableName, // parent table, for the comment
+					rel.ParentTable, // parent table, for the comment
 					rel.ChildStruct, rel.FKField, rel.FKFieldType,
 					rel.ChildStruct,
 					rel.ChildStruct, rel.ChildStruct, rel.ChildStruct, rel.FKField,
 				))
+
+				if rel.ParentColumn != "" {
+					buf.Write(Sprintf(
+						"// %s returns the join condition %s.%s = %s.%s.\n"+
+							"// Auto-generated by ormc — relation detected via db:\"ref=%s\".\n"+
+							"func %s() orm.Condition {\n"+
+							"\treturn orm.Eq(\"%s.%s\", orm.Col(\"%s.%s\"))\n"+
+							"}\n\n",
+						rel.JoinName,
+						rel.ChildTable, rel.FKColumn, rel.ParentTable, rel.ParentColumn,
+						rel.ParentTable,
+						rel.JoinName,
+						rel.ChildTable, rel.FKColumn, rel.ParentTable, rel.ParentColumn,
+					))
+				}
 			}
 		}
 	}
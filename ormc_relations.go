@@ -10,11 +10,15 @@ import (
 
 // RelationInfo describes a one-to-many relation loader to generate.
 type RelationInfo struct {
-	ChildStruct string // e.g. "Role"
-	FKField     string // e.g. "UserID"  (Go field name)
-	FKColumn    string // e.g. "user_id" (column name)
-	LoaderName  string // e.g. "ReadAllRoleByUserID"
-	FKFieldType string // e.g. "string", "int64"
+	ChildStruct  string // e.g. "Role"
+	ChildTable   string // e.g. "role"
+	FKField      string // e.g. "UserID"  (Go field name)
+	FKColumn     string // e.g. "user_id" (column name)
+	LoaderName   string // e.g. "ReadAllRoleByUserID"
+	JoinName     string // e.g. "JoinRoleByUserID"
+	FKFieldType  string // e.g. "string", "int64"
+	ParentTable  string // e.g. "user"
+	ParentColumn string // e.g. "id" (RefColumn, or the parent PK column)
 }
 
 // ResolveRelations (exported for testing) scans all parent SliceFields,
@@ -44,12 +48,21 @@ func (o *Ormc) ResolveRelations(all map[string]StructInfo) {
 				continue
 			}
 
+			parentColumn := fkField.RefColumn
+			if parentColumn == "" {
+				parentColumn = pkColumn(parentInfo)
+			}
+
 			rel := RelationInfo{
-				ChildStruct: childStructName,
-				FKField:     fkField.Name,
-				FKColumn:    fkField.ColumnName,
-				LoaderName:  Sprintf("ReadAll%sBy%s", childStructName, fkField.Name),
-				FKFieldType: fkField.GoType,
+				ChildStruct:  childStructName,
+				ChildTable:   childInfo.TableName,
+				FKField:      fkField.Name,
+				FKColumn:     fkField.ColumnName,
+				LoaderName:   Sprintf("ReadAll%sBy%s", childStructName, fkField.Name),
+				JoinName:     Sprintf("Join%sBy%s", childStructName, fkField.Name),
+				FKFieldType:  fkField.GoType,
+				ParentTable:  parentInfo.TableName,
+				ParentColumn: parentColumn,
 			}
 			childInfo.Relations = append(childInfo.Relations, rel)
 			all[childStructName] = childInfo
@@ -67,3 +80,13 @@ func findFKField(child StructInfo, parentTable string) *FieldInfo {
 	}
 	return nil
 }
+
+// pkColumn returns the column name of the PK field in info, or "" if none.
+func pkColumn(info StructInfo) string {
+	for _, f := range info.Fields {
+		if f.PK {
+			return f.ColumnName
+		}
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package orm
+
+import (
+	"slices"
+
+	"github.com/tinywasm/fmt"
+)
+
+// PageInfo describes one page returned by QB.Paginate.
+type PageInfo struct {
+	Total   int64 // rows matching the query conditions
+	Pages   int   // number of pages of PerPage rows
+	Page    int   // 1-based page that was read
+	PerPage int
+	HasNext bool
+	HasPrev bool
+}
+
+// Paginate reads the 1-based page of perPage rows and reports the total
+// row count. It runs Count and then the page query on a clone of qb, so qb
+// itself is left unchanged. Limit and Offset set on qb are replaced.
+//
+// Count and read are separate statements; run Paginate on the DB passed to
+// db.Tx when both must see the same snapshot:
+//
+//	err := db.Tx(func(tx *orm.DB) error {
+//		info, err = tx.Query(&User{}).OrderBy("id").Asc().Paginate(2, 20, newFn, onRow)
+//		return err
+//	})
+func (qb *QB) Paginate(page, perPage int, new func() Model, onRow func(Model)) (PageInfo, error) {
+	if page < 1 || perPage < 1 {
+		return PageInfo{}, fmt.Err(ErrValidation, "page", "invalid")
+	}
+	total, err := qb.clone().Count()
+	if err != nil {
+		return PageInfo{}, err
+	}
+
+	pages := int((total + int64(perPage) - 1) / int64(perPage))
+	info := PageInfo{
+		Total:   total,
+		Pages:   pages,
+		Page:    page,
+		PerPage: perPage,
+		HasNext: page < pages,
+		HasPrev: page > 1,
+	}
+	if page > pages {
+		return info, nil
+	}
+
+	pq := qb.clone()
+	pq.limit, pq.offset = perPage, (page-1)*perPage
+	return info, pq.ReadAll(new, onRow)
+}
+
+// clone returns a copy of qb that can be modified without affecting it.
+func (qb *QB) clone() *QB {
+	c := *qb
+	c.columns = slices.Clone(qb.columns)
+	c.distinctOn = slices.Clone(qb.distinctOn)
+	c.joins = slices.Clone(qb.joins)
+	c.conds = slices.Clone(qb.conds)
+	c.orderBy = slices.Clone(qb.orderBy)
+	c.groupBy = slices.Clone(qb.groupBy)
+	c.having = slices.Clone(qb.having)
+	return &c
+}
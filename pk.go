@@ -0,0 +1,65 @@
+package orm
+
+import "github.com/tinywasm/fmt"
+
+// pkIndex returns the schema index of the first PK field of m.
+func pkIndex(m Model) (int, error) {
+	for i, f := range m.Schema() {
+		if f.PK {
+			return i, nil
+		}
+	}
+	return -1, ErrNoPK
+}
+
+// pkCondition builds an equality condition on the PK field of m using its
+// current value.
+func pkCondition(m Model) (Condition, any, error) {
+	i, err := pkIndex(m)
+	if err != nil {
+		return Condition{}, nil, err
+	}
+	schema := m.Schema()
+	v := fmt.ReadValues(schema, m.Pointers())[i]
+	return Eq(schema[i].Name, v), v, nil
+}
+
+// Save inserts m when its PK holds the zero value, otherwise it updates the
+// row matching the PK.
+func (db *DB) Save(m Model) error {
+	if err := validate(ActionUpdate, m); err != nil {
+		return err
+	}
+	cond, v, err := pkCondition(m)
+	if err != nil {
+		return err
+	}
+	if fmt.IsZero(v) {
+		return db.Create(m)
+	}
+	return db.Update(m, cond)
+}
+
+// DeleteByPK deletes the row matching the PK value held by m.
+func (db *DB) DeleteByPK(m Model) error {
+	if err := validate(ActionDelete, m); err != nil {
+		return err
+	}
+	cond, _, err := pkCondition(m)
+	if err != nil {
+		return err
+	}
+	return db.Delete(m, cond)
+}
+
+// FindByPK reads the row whose PK equals id into m.
+func (db *DB) FindByPK(m Model, id any) error {
+	if err := validate(ActionReadOne, m); err != nil {
+		return err
+	}
+	i, err := pkIndex(m)
+	if err != nil {
+		return err
+	}
+	return db.Query(m).Where(m.Schema()[i].Name).Eq(id).ReadOne()
+}
@@ -1,16 +1,28 @@
 package orm
 
+import "github.com/tinywasm/fmt"
+
 // QB represents a query builder.
 // Consumers hold a *QB reference in variables for incremental building.
 type QB struct {
-	db      *DB
-	model   Model
-	conds   []Condition
-	orderBy []Order
-	groupBy []string
-	limit   int
-	offset  int
-	nextOr  bool
+	db             *DB
+	model          Model
+	columns        []string
+	distinct       bool
+	distinctOn     []string
+	joins          []Join
+	conds          []Condition
+	orderBy        []Order
+	groupBy        []string
+	having         []Condition
+	limit          int
+	offset         int
+	nextOr         bool
+	lock           LockMode
+	lockWait       LockWait
+	allowFullTable bool
+	scope          softDeleteScope
+	err            error // deferred builder error, reported by terminal methods
 }
 
 // Clause represents an intermediate state for building a query condition.
@@ -24,6 +36,33 @@ func (qb *QB) Where(column string) *Clause {
 	return &Clause{qb: qb, field: column}
 }
 
+// WhereCond adds prebuilt conditions, such as AnyOf(...) or Not(...).
+// Conditions wrapped with Or(c) keep their OR logic.
+func (qb *QB) WhereCond(conds ...Condition) *QB {
+	for _, c := range conds {
+		if c.logic == "OR" {
+			qb.nextOr = true
+		}
+		qb.addCondition(c)
+	}
+	return qb
+}
+
+// WhereGroup adds the conditions built by fn as a single parenthesised group.
+// Inside fn, Where and Or chain exactly as on the outer builder:
+//
+//	qb.Where("a").Eq(1).WhereGroup(func(g *orm.QB) {
+//		g.Where("b").Eq(2).Or().Where("c").Eq(3)
+//	}) // a = 1 AND (b = 2 OR c = 3)
+func (qb *QB) WhereGroup(fn func(g *QB)) *QB {
+	g := &QB{db: qb.db, model: qb.model}
+	fn(g)
+	if len(g.conds) == 0 {
+		return qb
+	}
+	return qb.addCondition(Condition{operator: OpGroup, children: g.conds})
+}
+
 // Or sets the next condition to use OR logic instead of AND.
 func (qb *QB) Or() *QB {
 	qb.nextOr = true
@@ -76,11 +115,41 @@ func (c *Clause) Like(value any) *QB {
 	return c.qb.addCondition(Like(c.field, value))
 }
 
-// In creates an IN condition.
+// In creates an IN condition. value may be a list or a *QB subquery.
 func (c *Clause) In(value any) *QB {
 	return c.qb.addCondition(In(c.field, value))
 }
 
+// NotIn creates a NOT IN condition. value may be a list or a *QB subquery.
+func (c *Clause) NotIn(value any) *QB {
+	return c.qb.addCondition(NotIn(c.field, value))
+}
+
+// NotLike creates a NOT LIKE condition.
+func (c *Clause) NotLike(value any) *QB {
+	return c.qb.addCondition(NotLike(c.field, value))
+}
+
+// ILike creates a case-insensitive LIKE condition.
+func (c *Clause) ILike(value any) *QB {
+	return c.qb.addCondition(ILike(c.field, value))
+}
+
+// IsNull creates an IS NULL condition.
+func (c *Clause) IsNull() *QB {
+	return c.qb.addCondition(IsNull(c.field))
+}
+
+// IsNotNull creates an IS NOT NULL condition.
+func (c *Clause) IsNotNull() *QB {
+	return c.qb.addCondition(IsNotNull(c.field))
+}
+
+// Between creates a BETWEEN lo AND hi condition.
+func (c *Clause) Between(lo, hi any) *QB {
+	return c.qb.addCondition(Between(c.field, lo, hi))
+}
+
 // Limit sets the limit for the query.
 func (qb *QB) Limit(limit int) *QB {
 	qb.limit = limit
@@ -116,69 +185,244 @@ func (o *OrderClause) Desc() *QB {
 	return o.qb
 }
 
+// Select restricts ReadOne and ReadAll to the given columns.
+// Only the matching Pointers() are scanned; other fields are left untouched.
+// Columns missing from the model schema fail any terminal call with ErrValidation.
+func (qb *QB) Select(columns ...string) *QB {
+	qb.columns = append(qb.columns, columns...)
+	return qb
+}
+
+// scanIndexes returns the Pointers() indexes to scan for the selected
+// columns, or nil when every column is read.
+func (qb *QB) scanIndexes() ([]int, error) {
+	if len(qb.columns) == 0 {
+		return nil, nil
+	}
+	schema := qb.model.Schema()
+	idx := make([]int, len(qb.columns))
+	for i, c := range qb.columns {
+		idx[i] = -1
+		for j, f := range schema {
+			if f.Name == c {
+				idx[i] = j
+				break
+			}
+		}
+		if idx[i] < 0 {
+			return nil, fmt.Err(ErrValidation, "unknown column", c)
+		}
+	}
+	return idx, nil
+}
+
+// scanPointers returns the Pointers() of m selected by idx.
+func scanPointers(m Model, idx []int) []any {
+	ptrs := m.Pointers()
+	if idx == nil {
+		return ptrs
+	}
+	sel := make([]any, len(idx))
+	for i, j := range idx {
+		sel[i] = ptrs[j]
+	}
+	return sel
+}
+
 // GroupBy adds a group by clause to the query.
 func (qb *QB) GroupBy(columns ...string) *QB {
 	qb.groupBy = append(qb.groupBy, columns...)
 	return qb
 }
 
-// ReadOne executes the query and returns a single result.
-func (qb *QB) ReadOne() error {
-	if err := validate(ActionReadOne, qb.model); err != nil {
+// Having adds conditions that filter the groups produced by GroupBy.
+// Use aggregate conditions such as CountGt("id", 5) or Aggregated(...).
+func (qb *QB) Having(conds ...Condition) *QB {
+	qb.having = append(qb.having, conds...)
+	return qb
+}
+
+// check validates the model for action and reports any error deferred by
+// builder methods such as After or by an embedded Subquery, an unknown
+// Select column, or a locking read outside a transaction.
+func (qb *QB) check(action Action) error {
+	if err := validate(action, qb.model); err != nil {
 		return err
 	}
+	if qb.err != nil {
+		return qb.err
+	}
+	if _, err := qb.scanIndexes(); err != nil {
+		return err
+	}
+	if err := subqueryErr(qb.conds); err != nil {
+		return err
+	}
+	if err := subqueryErr(qb.having); err != nil {
+		return err
+	}
+	return qb.checkLock(action)
+}
+
+// query builds the Query collected so far for the given action.
+func (qb *QB) query(action Action) Query {
+	columns := qb.columns
+	if len(qb.joins) > 0 {
+		// Joined reads name base columns explicitly so they stay unambiguous.
+		// Unknown columns were already reported by check.
+		idx, _ := qb.scanIndexes()
+		columns = qualifiedColumns(qb.model, idx)
+	}
 	q := Query{
-		Action:     ActionReadOne,
+		Action:     action,
 		Table:      qb.model.TableName(),
-		Conditions: qb.conds,
+		Columns:    columns,
+		Distinct:   qb.distinct,
+		DistinctOn: qb.distinctOn,
+		Joins:      qb.scopedJoins(),
+		Conditions: qb.scopedConditions(),
 		OrderBy:    qb.orderBy,
 		GroupBy:    qb.groupBy,
-		Limit:      1, // Force limit 1
+		Having:     qb.having,
+		Limit:      qb.limit,
 		Offset:     qb.offset,
 	}
+	if locks(action) {
+		q.Lock, q.LockWait = qb.lock, qb.lockWait
+	}
+	return q
+}
+
+// ReadOne executes the query and returns a single result.
+// Returns ErrNotFound when no row matches.
+func (qb *QB) ReadOne() error {
+	if err := qb.check(ActionReadOne); err != nil {
+		return err
+	}
+	idx, err := qb.scanIndexes()
+	if err != nil {
+		return err
+	}
+	q := qb.query(ActionReadOne)
+	q.Limit = 1 // Force limit 1
 	plan, err := qb.db.compiler.Compile(q, qb.model)
 	if err != nil {
 		return err
 	}
 
-	row := qb.db.exec.QueryRow(plan.Query, plan.Args...)
-	if err := row.Scan(qb.model.Pointers()...); err != nil {
-		return err
+	row := qb.db.queryRowPlan(plan)
+	if err := row.Scan(scanPointers(qb.model, idx)...); err != nil {
+		return qb.db.notFound(err)
 	}
 	return nil
 }
 
-// ReadAll executes the query and returns all results.
-func (qb *QB) ReadAll(new func() Model, onRow func(Model)) error {
-	if err := validate(ActionReadAll, qb.model); err != nil {
-		return err
+// readAllPlan validates and compiles the ActionReadAll query.
+// idx holds the Pointers() indexes selected by Select, or nil for all.
+func (qb *QB) readAllPlan() (plan Plan, idx []int, err error) {
+	if err := qb.check(ActionReadAll); err != nil {
+		return Plan{}, nil, err
 	}
-	q := Query{
-		Action:     ActionReadAll,
-		Table:      qb.model.TableName(),
-		Conditions: qb.conds,
-		OrderBy:    qb.orderBy,
-		GroupBy:    qb.groupBy,
-		Limit:      qb.limit,
-		Offset:     qb.offset,
+	if idx, err = qb.scanIndexes(); err != nil {
+		return Plan{}, nil, err
 	}
-	plan, err := qb.db.compiler.Compile(q, qb.model)
+	plan, err = qb.db.compiler.Compile(qb.query(ActionReadAll), qb.model)
+	return plan, idx, err
+}
+
+// ReadAll executes the query and returns all results.
+func (qb *QB) ReadAll(new func() Model, onRow func(Model)) error {
+	plan, idx, err := qb.readAllPlan()
 	if err != nil {
 		return err
 	}
 
-	rows, err := qb.db.exec.Query(plan.Query, plan.Args...)
+	return qb.db.eachRow(plan, func(rows Rows) error {
+		m := new()
+		if err := rows.Scan(scanPointers(m, idx)...); err != nil {
+			return err
+		}
+		onRow(m)
+		return nil
+	})
+}
+
+// eachRow runs a multi-row plan and calls fn for every row.
+// Iteration stops at the first error from fn or when the bound context is done.
+func (db *DB) eachRow(plan Plan, fn func(Rows) error) error {
+	rows, err := db.queryPlan(plan)
 	if err != nil {
 		return err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		m := new()
-		if err := rows.Scan(m.Pointers()...); err != nil {
+		if err := db.ctxErr(); err != nil {
+			return err
+		}
+		if err := fn(rows); err != nil {
 			return err
 		}
-		onRow(m)
 	}
 	return rows.Err()
 }
+
+// Count returns the number of rows matching the query conditions.
+// Limit, Offset and OrderBy are ignored. With Distinct it counts the distinct
+// combinations of the selected columns (every column when none were
+// selected); with DistinctOn, those of the DistinctOn columns. With GroupBy
+// it counts the groups left after Having, so Paginate pages over groups.
+func (qb *QB) Count() (int64, error) {
+	if err := qb.check(ActionCount); err != nil {
+		return 0, err
+	}
+	q := qb.query(ActionCount)
+	q.OrderBy, q.Limit, q.Offset = nil, 0, 0
+	switch {
+	case len(q.DistinctOn) > 0:
+		// DISTINCT ON returns one row per combination of its columns.
+		q.Columns, q.Distinct, q.DistinctOn = q.DistinctOn, true, nil
+	case q.Distinct && len(q.Columns) == 0:
+		q.Columns = make([]string, 0, len(qb.model.Schema()))
+		for _, f := range qb.model.Schema() {
+			q.Columns = append(q.Columns, f.Name)
+		}
+	case !q.Distinct:
+		q.Columns = nil
+	}
+	plan, err := qb.db.compiler.Compile(q, qb.model)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int64
+	if err := qb.db.queryRowPlan(plan).Scan(&n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// Exists reports whether at least one row matches the query conditions.
+// ActionExists is compiled to a statement that always returns exactly one
+// row holding a boolean, e.g. SELECT EXISTS (SELECT 1 FROM ... LIMIT 1), so
+// no "no rows" error is involved. ErrNotFound is still read as false.
+func (qb *QB) Exists() (bool, error) {
+	if err := qb.check(ActionExists); err != nil {
+		return false, err
+	}
+	q := qb.query(ActionExists)
+	q.Columns, q.OrderBy, q.Limit, q.Offset = nil, nil, 1, 0
+	plan, err := qb.db.compiler.Compile(q, qb.model)
+	if err != nil {
+		return false, err
+	}
+
+	var found bool
+	if err := qb.db.queryRowPlan(plan).Scan(&found); err != nil {
+		if err = qb.db.notFound(err); err == ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return found, nil
+}
@@ -12,6 +12,14 @@ const (
 	ActionCreateTable
 	ActionDropTable
 	ActionCreateDatabase
+	ActionCreateMany
+	ActionUpsert
+	// ActionCount returns one row: COUNT(*), COUNT(DISTINCT Columns...) when
+	// Distinct is set, or the number of groups when GroupBy is set, e.g.
+	// SELECT COUNT(*) FROM (SELECT 1 ... GROUP BY ... HAVING ...) t.
+	ActionCount
+	ActionExists // one row with a boolean: SELECT EXISTS (SELECT 1 ... LIMIT 1)
+	ActionAggregate
 )
 
 // Order represents a sort order for a query.
@@ -27,14 +35,25 @@ func (o Order) Dir() string    { return o.dir }
 // Query represents a database query to be executed by an Executor.
 // Planners read these fields to build Plans.
 type Query struct {
-	Action     Action
-	Table      string
-	Database   string
-	Columns    []string
-	Values     []any
-	Conditions []Condition
-	OrderBy    []Order
-	GroupBy    []string
-	Limit      int
-	Offset     int
+	Action          Action
+	Table           string
+	Database        string
+	Columns         []string
+	Distinct        bool     // SELECT DISTINCT
+	DistinctOn      []string // DISTINCT ON (...) columns, for engines that support it
+	Joins           []Join
+	Values          []any
+	Assignments     []Assignment  // ActionUpdate from QB.UpdateSet: SET expressions instead of Columns/Values
+	Batch           [][]any       // ActionCreateMany: one Values slice per row, aligned with Columns
+	ConflictColumns []string      // ActionUpsert: conflict target columns
+	UpdateColumns   []string      // ActionUpsert: columns overwritten when the conflict target matches
+	Aggregate       AggregateFunc // ActionAggregate: function applied to Columns[0]
+	Conditions      []Condition
+	OrderBy         []Order
+	GroupBy         []string
+	Having          []Condition // filters groups; conditions may carry an Aggregate
+	Limit           int
+	Offset          int
+	Lock            LockMode // row locking clause; only set on ActionReadOne / ActionReadAll
+	LockWait        LockWait // SKIP LOCKED / NOWAIT modifier for Lock
 }
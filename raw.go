@@ -0,0 +1,33 @@
+package orm
+
+// RawExec runs a hand-written write statement through the executor,
+// honouring the bound context.
+func (db *DB) RawExec(query string, args ...any) error {
+	return db.execPlan(Plan{Query: query, Args: args})
+}
+
+// RawQuery runs a hand-written single-row query and scans the result into
+// m.Pointers(). The query must return the columns of m.Schema() in order.
+// Returns ErrNotFound when no row matches.
+func (db *DB) RawQuery(m Model, query string, args ...any) error {
+	plan := Plan{Query: query, Args: args}
+	if err := db.queryRowPlan(plan).Scan(m.Pointers()...); err != nil {
+		return db.notFound(err)
+	}
+	return nil
+}
+
+// RawReadAll runs a hand-written query; for each row it calls new() to get a
+// fresh Model, scans into its Pointers(), then calls onRow(m).
+// The query must return the columns of the model schema in order.
+func (db *DB) RawReadAll(new func() Model, onRow func(Model), query string, args ...any) error {
+	plan := Plan{Query: query, Args: args}
+	return db.eachRow(plan, func(rows Rows) error {
+		m := new()
+		if err := rows.Scan(m.Pointers()...); err != nil {
+			return err
+		}
+		onRow(m)
+		return nil
+	})
+}
@@ -0,0 +1,138 @@
+package orm
+
+import (
+	"slices"
+
+	"github.com/tinywasm/fmt"
+)
+
+// SoftDeleter is an optional Model extension generated by ormc for structs
+// with a db:"softdelete" field. The column holds 0 for live rows and the DB
+// clock time (see SetClock) once the row is deleted.
+//
+// For soft-deletable models, Delete and QB.DeleteAll set the column instead
+// of removing rows, and every QB operation excludes deleted rows unless
+// WithDeleted or OnlyDeleted is used.
+type SoftDeleter interface {
+	SoftDeleteColumn() string
+}
+
+// softDeleteColumn returns the soft-delete column of m, or "" if m is
+// hard-deleted.
+func softDeleteColumn(m Model) string {
+	if sd, ok := m.(SoftDeleter); ok {
+		return sd.SoftDeleteColumn()
+	}
+	return ""
+}
+
+// softDeleteScope selects which rows a QB sees on a soft-deletable model.
+type softDeleteScope int
+
+const (
+	scopeLive softDeleteScope = iota
+	scopeAll
+	scopeDeleted
+)
+
+// WithDeleted includes soft-deleted rows in the query.
+func (qb *QB) WithDeleted() *QB {
+	qb.scope = scopeAll
+	return qb
+}
+
+// OnlyDeleted restricts the query to soft-deleted rows.
+func (qb *QB) OnlyDeleted() *QB {
+	qb.scope = scopeDeleted
+	return qb
+}
+
+// scopedConditions returns the query conditions with the soft-delete filter
+// of the current scope appended.
+func (qb *QB) scopedConditions() []Condition {
+	col := softDeleteColumn(qb.model)
+	if col == "" || qb.scope == scopeAll {
+		return qb.conds
+	}
+	if len(qb.joins) > 0 {
+		col = Qualify(qb.model.TableName(), col)
+	}
+	filter := Eq(col, 0)
+	if qb.scope == scopeDeleted {
+		filter = Gt(col, 0)
+	}
+	return withFilter(qb.conds, filter)
+}
+
+// withFilter returns conds followed by filter. Conditions joined with OR are
+// grouped first so the filter applies to all of them.
+func withFilter(conds []Condition, filter Condition) []Condition {
+	for _, c := range conds {
+		if c.logic == "OR" {
+			return []Condition{{operator: OpGroup, children: conds, logic: "AND"}, filter}
+		}
+	}
+	return append(slices.Clip(conds), filter)
+}
+
+// scopedJoins returns the joins with live-row filters added to the ON clause
+// of soft-deletable joined models, so a LeftJoin keeps base rows whose match
+// was deleted. WithDeleted disables the filters; OnlyDeleted applies only to
+// the base model.
+func (qb *QB) scopedJoins() []Join {
+	if qb.scope == scopeAll {
+		return qb.joins
+	}
+	var joins []Join
+	for i, j := range qb.joins {
+		col := softDeleteColumn(j.model)
+		if col == "" {
+			continue
+		}
+		if joins == nil {
+			joins = slices.Clone(qb.joins)
+		}
+		joins[i].on = AllOf(j.on, Eq(Qualify(j.model.TableName(), col), 0))
+	}
+	if joins == nil {
+		return qb.joins
+	}
+	return joins
+}
+
+// softDelete turns a delete query on m into an update that stamps col, and
+// the updated_at column when m has one, with the DB clock.
+func (db *DB) softDelete(q Query, m Model, col string) Query {
+	now := db.now()
+	q.Action = ActionUpdate
+	q.Assignments = withUpdatedAt(m, []Assignment{{column: col, kind: AssignNow, value: now}}, now)
+	return q
+}
+
+// Restore clears the soft-delete column of the deleted rows matching the
+// conditions and sets updated_at like any update. Models without a
+// db:"softdelete" field fail with ErrValidation.
+func (db *DB) Restore(m Model, cond Condition, rest ...Condition) error {
+	if err := validate(ActionUpdate, m); err != nil {
+		return err
+	}
+	col := softDeleteColumn(m)
+	if col == "" {
+		return fmt.Err(ErrValidation, "soft delete", "not", "supported")
+	}
+	conds := append([]Condition{cond}, rest...)
+	if err := subqueryErr(conds); err != nil {
+		return err
+	}
+	q := Query{
+		Action:      ActionUpdate,
+		Table:       m.TableName(),
+		Assignments: withUpdatedAt(m, []Assignment{Set(col, 0)}, db.now()),
+		Conditions:  withFilter(conds, Gt(col, 0)),
+	}
+	plan, err := db.compiler.Compile(q, m)
+	if err != nil {
+		return err
+	}
+	return db.execPlan(plan)
+}
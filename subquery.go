@@ -0,0 +1,84 @@
+package orm
+
+// Subquery is a nested query used as a Condition value by In, NotIn,
+// Exists and NotExists. Compilers compile Query() with the same Compiler
+// and merge the resulting Args into the outer Plan.Args.
+type Subquery struct {
+	query Query
+	model Model
+	err   error // builder error, reported by the outer query's terminal call
+}
+
+func (s Subquery) Query() Query { return s.query }
+func (s Subquery) Model() Model { return s.model }
+
+// Subquery returns the query collected so far as a nested ActionReadAll query.
+// Use Select to choose the column compared by In/NotIn. Errors of the
+// sub-builder (invalid model, unknown Select column, deferred errors such as
+// a mismatched After cursor) make the outer terminal call fail.
+func (qb *QB) Subquery() Subquery {
+	if err := validate(ActionReadAll, qb.model); err != nil {
+		return Subquery{model: qb.model, err: err}
+	}
+	err := qb.err
+	if err == nil {
+		_, err = qb.scanIndexes()
+	}
+	if err == nil {
+		err = subqueryErr(qb.conds)
+	}
+	q := qb.query(ActionReadAll)
+	// Locks belong to the outer statement; a nested SELECT never carries one.
+	q.Lock, q.LockWait = LockNone, LockWaitDefault
+	return Subquery{query: q, model: qb.model, err: err}
+}
+
+// subqueryErr returns the first error recorded on a Subquery value among
+// conds, including grouped conditions.
+func subqueryErr(conds []Condition) error {
+	for _, c := range conds {
+		if s, ok := c.value.(Subquery); ok && s.err != nil {
+			return s.err
+		}
+		if err := subqueryErr(c.children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subqueryValue converts a *QB value into a Subquery; other values pass through.
+func subqueryValue(v any) any {
+	if qb, ok := v.(*QB); ok {
+		return qb.Subquery()
+	}
+	return v
+}
+
+// Exists creates a condition that matches when sub returns at least one row.
+func Exists(sub *QB) Condition {
+	return Condition{
+		operator: OpExists,
+		value:    sub.Subquery(),
+		logic:    "AND",
+	}
+}
+
+// NotExists creates a condition that matches when sub returns no rows.
+func NotExists(sub *QB) Condition {
+	return Condition{
+		operator: OpNotExists,
+		value:    sub.Subquery(),
+		logic:    "AND",
+	}
+}
+
+// WhereExists adds an EXISTS (sub) condition.
+func (qb *QB) WhereExists(sub *QB) *QB {
+	return qb.addCondition(Exists(sub))
+}
+
+// WhereNotExists adds a NOT EXISTS (sub) condition.
+func (qb *QB) WhereNotExists(sub *QB) *QB {
+	return qb.addCondition(NotExists(sub))
+}
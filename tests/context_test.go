@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tinywasm/orm"
+)
+
+func RunContextTests(t *testing.T) {
+	model := &MockModel{Table: "user"}
+
+	t.Run("ContextExecutor receives bound context", func(t *testing.T) {
+		mockExec := &MockContextExecutor{}
+		db := orm.New(mockExec, &MockCompiler{})
+
+		type key struct{}
+		ctx := context.WithValue(context.Background(), key{}, "v")
+
+		if err := db.WithContext(ctx).Delete(model, orm.Eq("id", 1)); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if mockExec.LastCtx != ctx {
+			t.Error("Expected ExecContext to receive the bound context")
+		}
+
+		mockExec.LastCtx = nil
+		if err := db.WithContext(ctx).Query(model).ReadOne(); err != nil {
+			t.Fatalf("ReadOne failed: %v", err)
+		}
+		if mockExec.LastCtx != ctx {
+			t.Error("Expected QueryRowContext to receive the bound context")
+		}
+
+		mockExec.LastCtx = nil
+		if err := db.WithContext(ctx).Query(model).ReadAll(nil, nil); err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		if mockExec.LastCtx != ctx {
+			t.Error("Expected QueryContext to receive the bound context")
+		}
+
+		// The original DB is left untouched.
+		if db.Context() != context.Background() {
+			t.Error("Expected WithContext to return a copy")
+		}
+	})
+
+	t.Run("ContextExecutor without ContextResultExecutor keeps the context", func(t *testing.T) {
+		mockExec := &MockContextPlainResultExecutor{}
+		db := orm.New(mockExec, &MockCompiler{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		n, err := db.WithContext(ctx).Query(model).Where("id").Eq(1).DeleteAll()
+		if err != nil {
+			t.Fatalf("DeleteAll failed: %v", err)
+		}
+		if mockExec.LastCtx != ctx || mockExec.ExecResultCalled {
+			t.Error("Expected ExecContext to run instead of ExecResult")
+		}
+		if n != -1 {
+			t.Errorf("Expected unknown affected rows, got %d", n)
+		}
+
+		// Without a bound context the Result is still reported.
+		if n, err := db.Query(model).Where("id").Eq(1).DeleteAll(); err != nil || n != 1 || !mockExec.ExecResultCalled {
+			t.Errorf("Expected ExecResult without a context, got %d %v", n, err)
+		}
+	})
+
+	t.Run("Cancelled context on plain Executor", func(t *testing.T) {
+		mockExec := &MockExecutor{}
+		db := orm.New(mockExec, &MockCompiler{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		cdb := db.WithContext(ctx)
+
+		if err := cdb.Create(model); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled from Create, got %v", err)
+		}
+		if err := cdb.Query(model).ReadOne(); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled from ReadOne, got %v", err)
+		}
+		if err := cdb.Query(model).ReadAll(nil, nil); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled from ReadAll, got %v", err)
+		}
+		if len(mockExec.ExecutedQueries) != 0 {
+			t.Errorf("Expected no queries to reach the executor, got %d", len(mockExec.ExecutedQueries))
+		}
+	})
+
+	t.Run("ReadAll stops when context is cancelled mid-iteration", func(t *testing.T) {
+		mockExec := &MockExecutor{ReturnQueryRows: &MockRows{Count: 5}}
+		ctx, cancel := context.WithCancel(context.Background())
+		db := orm.New(mockExec, &MockCompiler{}).WithContext(ctx)
+
+		rows := 0
+		err := db.Query(model).ReadAll(
+			func() orm.Model { return &MockModel{} },
+			func(orm.Model) {
+				rows++
+				if rows == 2 {
+					cancel()
+				}
+			},
+		)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if rows != 2 {
+			t.Errorf("Expected iteration to stop after 2 rows, got %d", rows)
+		}
+	})
+
+	t.Run("Tx inherits context", func(t *testing.T) {
+		bound := &MockTxBoundExecutor{}
+		db := orm.New(&MockTxExecutor{Bound: bound}, &MockCompiler{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := db.WithContext(ctx).Tx(func(tx *orm.DB) error { return nil })
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled from Tx, got %v", err)
+		}
+		if bound.CommitCalled {
+			t.Error("Expected Commit NOT to be called")
+		}
+
+		ctx = context.WithValue(context.Background(), struct{}{}, 1)
+		err = db.WithContext(ctx).Tx(func(tx *orm.DB) error {
+			if tx.Context() != ctx {
+				t.Error("Expected tx DB to carry the bound context")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Tx failed: %v", err)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"strings"
@@ -329,7 +330,7 @@ func RunCoreTests(t *testing.T) {
 		tests := []struct {
 			name     string
 			cond     orm.Condition
-			expected string
+			expected orm.Operator
 			val      any
 		}{
 			{"Neq", orm.Neq("a", 1), "!=", 1},
@@ -338,6 +339,12 @@ func RunCoreTests(t *testing.T) {
 			{"Lte", orm.Lte("d", 4), "<=", 4},
 			{"Like", orm.Like("e", "%test%"), "LIKE", "%test%"},
 			{"In", orm.In("f", []int{1, 2}), "IN", []int{1, 2}},
+			{"NotIn", orm.NotIn("g", []int{3}), orm.OpNotIn, []int{3}},
+			{"NotLike", orm.NotLike("h", "a%"), orm.OpNotLike, "a%"},
+			{"ILike", orm.ILike("i", "A%"), orm.OpILike, "A%"},
+			{"IsNull", orm.IsNull("j"), orm.OpIsNull, nil},
+			{"IsNotNull", orm.IsNotNull("k"), orm.OpIsNotNull, nil},
+			{"Between", orm.Between("l", 1, 9), orm.OpBetween, []any{1, 9}},
 		}
 
 		for _, tc := range tests {
@@ -569,6 +576,95 @@ func RunCoreTests(t *testing.T) {
 		}
 	})
 
+	// Test ReadOne ErrNotFound normalization
+	t.Run("ReadOne ErrNotFound", func(t *testing.T) {
+		model := &MockModel{Table: "user"}
+		noRows := errors.New("sql: no rows in result set")
+
+		// Executor with NotFoundChecker translates its native error.
+		mockExec := &MockNotFoundExecutor{NotFoundErr: noRows}
+		mockExec.ReturnQueryRow = &MockScanner{ScanErr: noRows}
+		db := orm.New(mockExec, &MockCompiler{})
+		if err := db.Query(model).ReadOne(); !errors.Is(err, orm.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+
+		// Other scan errors pass through unchanged.
+		mockExec.ReturnQueryRow = &MockScanner{ScanErr: errors.New("scan err")}
+		if err := db.Query(model).ReadOne(); err == nil || err.Error() != "scan err" {
+			t.Errorf("Expected scan err, got %v", err)
+		}
+
+		// Inside Tx the root executor's checker still applies.
+		bound := &MockTxBoundExecutor{MockExecutor: MockExecutor{ReturnQueryRow: &MockScanner{ScanErr: noRows}}}
+		txExec := &MockTxNotFoundExecutor{MockTxExecutor: MockTxExecutor{Bound: bound}, NotFoundErr: noRows}
+		err := orm.New(txExec, &MockCompiler{}).Tx(func(tx *orm.DB) error {
+			return tx.Query(model).ReadOne()
+		})
+		if !errors.Is(err, orm.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound inside Tx, got %v", err)
+		}
+
+		// Executors may return ErrNotFound directly.
+		db2 := orm.New(&MockExecutor{ReturnQueryRow: &MockScanner{ScanErr: orm.ErrNotFound}}, &MockCompiler{})
+		if err := db2.Query(model).ReadOne(); !errors.Is(err, orm.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	// Test Raw queries scanning into models
+	t.Run("Raw queries", func(t *testing.T) {
+		mockExec := &MockExecutor{
+			ReturnQueryRow:  &MockScanner{Values: []any{int64(1), "Alice"}},
+			ReturnQueryRows: &MockRows{Count: 2, Data: [][]any{{int64(1), "a"}, {int64(2), "b"}}},
+		}
+		db := orm.New(mockExec, &MockCompiler{})
+
+		if err := db.RawExec("UPDATE auto SET name = ?", "x"); err != nil {
+			t.Fatalf("RawExec failed: %v", err)
+		}
+		if mockExec.ExecutedQueries[0] != "UPDATE auto SET name = ?" || mockExec.ExecutedArgs[0][0] != "x" {
+			t.Errorf("Unexpected RawExec call: %v %v", mockExec.ExecutedQueries[0], mockExec.ExecutedArgs[0])
+		}
+
+		m := &AutoIncModel{}
+		if err := db.RawQuery(m, "SELECT id, name FROM auto WHERE id = ?", 1); err != nil {
+			t.Fatalf("RawQuery failed: %v", err)
+		}
+		if m.ID != 1 || m.Name != "Alice" {
+			t.Errorf("Expected scanned model, got %+v", m)
+		}
+
+		var names []string
+		err := db.RawReadAll(
+			func() orm.Model { return &AutoIncModel{} },
+			func(m orm.Model) { names = append(names, m.(*AutoIncModel).Name) },
+			"SELECT id, name FROM auto",
+		)
+		if err != nil {
+			t.Fatalf("RawReadAll failed: %v", err)
+		}
+		if !reflect.DeepEqual(names, []string{"a", "b"}) {
+			t.Errorf("Expected [a b], got %v", names)
+		}
+
+		noRows := errors.New("no rows")
+		nf := &MockNotFoundExecutor{NotFoundErr: noRows}
+		nf.ReturnQueryRow = &MockScanner{ScanErr: noRows}
+		if err := orm.New(nf, &MockCompiler{}).RawQuery(&AutoIncModel{}, "SELECT 1"); !errors.Is(err, orm.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+
+		ctxExec := &MockContextExecutor{}
+		ctx := context.WithValue(context.Background(), struct{}{}, 1)
+		if err := orm.New(ctxExec, &MockCompiler{}).WithContext(ctx).RawExec("DELETE FROM t"); err != nil {
+			t.Fatalf("RawExec failed: %v", err)
+		}
+		if ctxExec.LastCtx != ctx {
+			t.Error("Expected RawExec to honour the bound context")
+		}
+	})
+
 	// 17. Test Close and RawExecutor
 	t.Run("Close and RawExecutor", func(t *testing.T) {
 		mockExec := &MockExecutor{ReturnCloseErr: errors.New("close err")}
@@ -128,3 +128,33 @@ type WithPointers struct {
 	Count *int    // pointer to primitive -> should be skipped with warning
 	Addr  *Address // pointer to struct -> FieldStruct
 }
+
+// Article covers db:"softdelete" (SoftDeleteColumn generation).
+type Article struct {
+	ID        int64 `db:"pk"`
+	Title     string
+	DeletedAt int64 `db:"softdelete"`
+}
+
+type BadSoftDelete struct {
+	ID        string `db:"pk"`
+	DeletedAt string `db:"softdelete"`
+}
+
+// Stamped covers db:"created_at" and db:"updated_at".
+type Stamped struct {
+	ID        int64 `db:"pk"`
+	CreatedAt int64 `db:"created_at"`
+	UpdatedAt int64 `db:"updated_at"`
+}
+
+type BadStamp struct {
+	ID        string `db:"pk"`
+	UpdatedAt string `db:"updated_at"`
+}
+
+// NarrowStamp declares created_at on an int32, too narrow for nanoseconds.
+type NarrowStamp struct {
+	ID        int64 `db:"pk"`
+	CreatedAt int32 `db:"created_at"`
+}
@@ -8,4 +8,7 @@ import (
 
 func TestCoreLogic_Stlib(t *testing.T) {
 	RunCoreTests(t)
+	RunContextTests(t)
+	RunWriteTests(t)
+	RunQBTests(t)
 }
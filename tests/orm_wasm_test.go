@@ -8,4 +8,7 @@ import (
 
 func TestCoreLogic_Wasm(t *testing.T) {
 	RunCoreTests(t)
+	RunContextTests(t)
+	RunWriteTests(t)
+	RunQBTests(t)
 }
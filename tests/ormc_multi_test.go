@@ -154,7 +154,7 @@ func TestQB_ClauseChain(t *testing.T) {
 		conds := mockCompiler.LastQuery.Conditions
 		expected := []struct {
 			field string
-			op    string
+			op    orm.Operator
 		}{
 			{"a", "!="},
 			{"b", ">"},
@@ -66,6 +66,37 @@ func TestOrmc_RelationLoader(t *testing.T) {
 		if !strings.Contains(string(content), "ReadAllMockChildByMockParentID") {
 			t.Error("relation loader not found in generated output")
 		}
+		expected := []string{
+			"func JoinMockChildByMockParentID() orm.Condition {",
+			`return orm.Eq("mock_child.mock_parent_id", orm.Col("mock_parent.id"))`,
+			`db:"ref=mock_parent"`,
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("missing: %s\nContent:\n%s", e, content)
+			}
+		}
+	})
+
+	t.Run("ResolveRelations records parent join column", func(t *testing.T) {
+		o := orm.NewOrmc()
+
+		parent, _ := o.ParseStruct("MockParent", "mock_generator_model.go")
+		child, _ := o.ParseStruct("MockChild", "mock_generator_model.go")
+
+		all := map[string]orm.StructInfo{
+			"MockParent": parent,
+			"MockChild":  child,
+		}
+		o.ResolveRelations(all)
+
+		rel := all["MockChild"].Relations[0]
+		if rel.ParentTable != "mock_parent" || rel.ParentColumn != "id" || rel.ChildTable != "mock_child" {
+			t.Errorf("unexpected join metadata: %+v", rel)
+		}
+		if rel.JoinName != "JoinMockChildByMockParentID" {
+			t.Errorf("unexpected join name: %s", rel.JoinName)
+		}
 	})
 
 	t.Run("No FK in child → warning log, no relation generated", func(t *testing.T) {
@@ -43,6 +43,9 @@ func TestOrmc(t *testing.T) {
 			"func (m *LoginForm) TableName() string",
 			"func ReadOneLoginForm",
 			"func ReadAllLoginForm",
+			"func IterLoginForm",
+			"func PaginateLoginForm",
+			"\"iter\"",
 			"var LoginForm_ =",
 			"\"github.com/tinywasm/orm\"", // Import should be missing
 		}
@@ -127,6 +130,11 @@ func TestOrmc(t *testing.T) {
 			"ID: \"id\"",
 			"func ReadOneUser(qb *orm.QB, model *User) (*User, error) {",
 			"func ReadAllUser(qb *orm.QB) ([]*User, error) {",
+			"\"iter\"",
+			"func IterUser(qb *orm.QB) iter.Seq2[*User, error] {",
+			"for m, err := range qb.Iter(func() orm.Model { return &User{} }) {",
+			"func PaginateUser(qb *orm.QB, page, perPage int) ([]*User, orm.PageInfo, error) {",
+			"info, err := qb.Paginate(page, perPage,",
 		}
 
 		for _, expected := range expectedStrings {
@@ -202,6 +210,84 @@ func TestOrmc(t *testing.T) {
 		}
 	})
 
+	t.Run("Soft delete tag", func(t *testing.T) {
+		err := orm.NewOrmc().GenerateForStruct("Article", "mock_generator_model.go")
+		if err != nil {
+			t.Fatalf("Failed to generate code for Article: %v", err)
+		}
+
+		outFile := "mock_generator_model_orm.go"
+		contentBytes, err := os.ReadFile(outFile)
+		if err != nil {
+			t.Fatalf("Failed to read generated file: %v", err)
+		}
+		defer os.Remove(outFile)
+
+		content := string(contentBytes)
+		expectedStrings := []string{
+			`{Name: "deleted_at", Type: fmt.FieldInt},`,
+			`func (m *Article) SoftDeleteColumn() string { return "deleted_at" }`,
+		}
+		for _, expected := range expectedStrings {
+			if !strings.Contains(content, expected) {
+				t.Errorf("Generated file missing expected string: %s", expected)
+			}
+		}
+
+		info, err := orm.NewOrmc().ParseStruct("Article", "mock_generator_model.go")
+		if err != nil || !info.Fields[2].SoftDelete || info.Fields[1].SoftDelete {
+			t.Errorf("Expected only DeletedAt to be SoftDelete, got %+v %v", info.Fields, err)
+		}
+	})
+
+	t.Run("Bad SoftDelete", func(t *testing.T) {
+		_, err := orm.NewOrmc().ParseStruct("BadSoftDelete", "mock_generator_model.go")
+		if err == nil || !strings.Contains(err.Error(), "softdelete requires an int field") {
+			t.Errorf("Expected error about softdelete on a non-int field, got %v", err)
+		}
+	})
+
+	t.Run("Timestamp tags", func(t *testing.T) {
+		err := orm.NewOrmc().GenerateForStruct("Stamped", "mock_generator_model.go")
+		if err != nil {
+			t.Fatalf("Failed to generate code for Stamped: %v", err)
+		}
+
+		outFile := "mock_generator_model_orm.go"
+		contentBytes, err := os.ReadFile(outFile)
+		if err != nil {
+			t.Fatalf("Failed to read generated file: %v", err)
+		}
+		defer os.Remove(outFile)
+
+		content := string(contentBytes)
+		expectedStrings := []string{
+			`{Name: "created_at", Type: fmt.FieldInt},`,
+			`func (m *Stamped) CreatedAtColumn() string { return "created_at" }`,
+			`func (m *Stamped) UpdatedAtColumn() string { return "updated_at" }`,
+		}
+		for _, expected := range expectedStrings {
+			if !strings.Contains(content, expected) {
+				t.Errorf("Generated file missing expected string: %s", expected)
+			}
+		}
+		if strings.Contains(content, "SoftDeleteColumn") {
+			t.Error("Stamped must not implement SoftDeleter")
+		}
+	})
+
+	t.Run("Bad timestamp type", func(t *testing.T) {
+		_, err := orm.NewOrmc().ParseStruct("BadStamp", "mock_generator_model.go")
+		if err == nil || !strings.Contains(err.Error(), "updated_at requires an int field") {
+			t.Errorf("Expected error about updated_at on a non-int field, got %v", err)
+		}
+
+		_, err = orm.NewOrmc().ParseStruct("NarrowStamp", "mock_generator_model.go")
+		if err == nil || !strings.Contains(err.Error(), "created_at requires an int64 or uint64 field") {
+			t.Errorf("Expected error about created_at on an int32 field, got %v", err)
+		}
+	})
+
 	t.Run("Unsupported Type", func(t *testing.T) {
 		err := orm.NewOrmc().GenerateForStruct("Unsupp", "mock_generator_model.go")
 		if err != nil {
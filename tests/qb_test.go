@@ -0,0 +1,1015 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/orm"
+)
+
+func RunQBTests(t *testing.T) {
+	model := &MockModel{Table: "user"}
+
+	t.Run("Count", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRow: &MockScanner{Values: []any{int64(7)}}}
+		db := orm.New(mockExec, mockCompiler)
+
+		n, err := db.Query(model).
+			Where("age").Gt(18).
+			GroupBy("country").
+			OrderBy("name").Asc().
+			Limit(10).
+			Count()
+		if err != nil {
+			t.Fatalf("Count failed: %v", err)
+		}
+		if n != 7 {
+			t.Errorf("Expected 7, got %d", n)
+		}
+		q := mockCompiler.LastQuery
+		if q.Action != orm.ActionCount {
+			t.Errorf("Expected ActionCount, got %v", q.Action)
+		}
+		// With GroupBy the compiler counts groups, so GroupBy and Having stay.
+		if len(q.Conditions) != 1 || len(q.GroupBy) != 1 {
+			t.Errorf("Expected conditions and group by to be kept, got %v %v", q.Conditions, q.GroupBy)
+		}
+		if q.Limit != 0 || len(q.OrderBy) != 0 {
+			t.Errorf("Expected limit and order to be dropped, got %d %v", q.Limit, q.OrderBy)
+		}
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRow: &MockScanner{Values: []any{true}}}
+		db := orm.New(mockExec, mockCompiler)
+
+		ok, err := db.Query(model).Where("email").Eq("a@b.c").Exists()
+		if err != nil || !ok {
+			t.Fatalf("Expected true, got %v %v", ok, err)
+		}
+		if mockCompiler.LastQuery.Action != orm.ActionExists || mockCompiler.LastQuery.Limit != 1 {
+			t.Errorf("Expected ActionExists with limit 1, got %v %d", mockCompiler.LastQuery.Action, mockCompiler.LastQuery.Limit)
+		}
+
+		// Plain executor without NotFoundChecker: the EXISTS row reports false.
+		mockExec.ReturnQueryRow = &MockScanner{Values: []any{false}}
+		ok, err = db.Query(model).Where("email").Eq("x@y.z").Exists()
+		if err != nil || ok {
+			t.Errorf("Expected false without error, got %v %v", ok, err)
+		}
+
+		mockExec.ReturnQueryRow = &MockScanner{ScanErr: orm.ErrNotFound}
+		ok, err = db.Query(model).Exists()
+		if err != nil || ok {
+			t.Errorf("Expected false without error, got %v %v", ok, err)
+		}
+
+		mockExec.ReturnQueryRow = &MockScanner{ScanErr: errors.New("scan err")}
+		if _, err := db.Query(model).Exists(); err == nil || err.Error() != "scan err" {
+			t.Errorf("Expected scan err, got %v", err)
+		}
+	})
+
+	t.Run("Count and Exists errors", func(t *testing.T) {
+		db := orm.New(&MockExecutor{}, &MockCompiler{ReturnErr: errors.New("plan err")})
+		if _, err := db.Query(model).Count(); err == nil || err.Error() != "plan err" {
+			t.Errorf("Expected plan err, got %v", err)
+		}
+		if _, err := db.Query(model).Exists(); err == nil || err.Error() != "plan err" {
+			t.Errorf("Expected plan err, got %v", err)
+		}
+		if _, err := db.Query(&MockModel{}).Count(); !errors.Is(err, orm.ErrEmptyTable) {
+			t.Errorf("Expected ErrEmptyTable, got %v", err)
+		}
+	})
+
+	t.Run("Aggregates", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRow: &MockScanner{Values: []any{12.5}}}
+		db := orm.New(mockExec, mockCompiler)
+		orders := &MockModel{Table: "orders", Sch: []fmt.Field{{Name: "customer"}, {Name: "total"}}}
+
+		tests := []struct {
+			fn   func(*orm.QB) (float64, error)
+			want orm.AggregateFunc
+			name string
+		}{
+			{func(qb *orm.QB) (float64, error) { return qb.Sum("total") }, orm.AggregateSum, "SUM"},
+			{func(qb *orm.QB) (float64, error) { return qb.Avg("total") }, orm.AggregateAvg, "AVG"},
+			{func(qb *orm.QB) (float64, error) { return qb.Min("total") }, orm.AggregateMin, "MIN"},
+			{func(qb *orm.QB) (float64, error) { return qb.Max("total") }, orm.AggregateMax, "MAX"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				v, err := tc.fn(db.Query(orders).Where("customer").Eq("a"))
+				if err != nil {
+					t.Fatalf("%s failed: %v", tc.name, err)
+				}
+				if v != 12.5 {
+					t.Errorf("Expected 12.5, got %v", v)
+				}
+				q := mockCompiler.LastQuery
+				if q.Action != orm.ActionAggregate || q.Aggregate != tc.want {
+					t.Errorf("Expected ActionAggregate %v, got %v %v", tc.want, q.Action, q.Aggregate)
+				}
+				if q.Aggregate.String() != tc.name {
+					t.Errorf("Expected %s, got %s", tc.name, q.Aggregate.String())
+				}
+				if len(q.Columns) != 1 || q.Columns[0] != "total" || len(q.Conditions) != 1 {
+					t.Errorf("Expected column total and 1 condition, got %v %v", q.Columns, q.Conditions)
+				}
+			})
+		}
+
+		if _, err := db.Query(orders).Sum("missing"); err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for unknown column, got %v", err)
+		}
+	})
+
+	t.Run("Aggregates map NULL to zero", func(t *testing.T) {
+		mockExec := &MockExecutor{ReturnQueryRow: &MockScanner{Values: []any{nil}}}
+		db := orm.New(mockExec, &MockCompiler{})
+		orders := &MockModel{Table: "orders", Sch: []fmt.Field{{Name: "customer"}, {Name: "total"}}}
+
+		v, err := db.Query(orders).Where("customer").Eq("nobody").Sum("total")
+		if err != nil || v != 0 {
+			t.Errorf("Expected 0 for a NULL sum, got %v %v", v, err)
+		}
+
+		for _, raw := range []any{int64(7), []byte("7"), "7", float32(7)} {
+			mockExec.ReturnQueryRow = &MockScanner{Values: []any{raw}}
+			if v, err := db.Query(orders).Max("total"); err != nil || v != 7 {
+				t.Errorf("Expected 7 from %T, got %v %v", raw, v, err)
+			}
+		}
+
+		mockExec.ReturnQueryRow = &MockScanner{Values: []any{true}}
+		if _, err := db.Query(orders).Avg("total"); err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for a non-numeric result, got %v", err)
+		}
+
+		mockExec.ReturnQueryRows = &MockRows{Count: 1, Data: [][]any{{"a", nil}}}
+		got := map[any]float64{"a": -1}
+		err = db.Query(orders).GroupBy("customer").
+			GroupAggregate(orm.AggregateMin, "total", func(key any, v float64) { got[key] = v })
+		if err != nil || got["a"] != 0 {
+			t.Errorf("Expected NULL group value as 0, got %v %v", got, err)
+		}
+	})
+
+	t.Run("GroupAggregate", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRows: &MockRows{
+			Count: 2,
+			Data:  [][]any{{"a", 10.0}, {"b", 20.0}},
+		}}
+		db := orm.New(mockExec, mockCompiler)
+		orders := &MockModel{Table: "orders", Sch: []fmt.Field{{Name: "customer"}, {Name: "total"}}}
+
+		got := map[any]float64{}
+		err := db.Query(orders).
+			GroupBy("customer").
+			GroupAggregate(orm.AggregateSum, "total", func(key any, v float64) { got[key] = v })
+		if err != nil {
+			t.Fatalf("GroupAggregate failed: %v", err)
+		}
+		if got["a"] != 10 || got["b"] != 20 {
+			t.Errorf("Unexpected groups: %v", got)
+		}
+		if len(mockCompiler.LastQuery.GroupBy) != 1 {
+			t.Errorf("Expected GroupBy to be kept, got %v", mockCompiler.LastQuery.GroupBy)
+		}
+
+		err = db.Query(orders).GroupAggregate(orm.AggregateSum, "total", func(any, float64) {})
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error without GroupBy, got %v", err)
+		}
+	})
+
+	t.Run("Select scans only selected columns", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRow: &MockScanner{Values: []any{"Alice"}}}
+		db := orm.New(mockExec, mockCompiler)
+
+		m := &AutoIncModel{ID: 3}
+		if err := db.Query(m).Select("name").ReadOne(); err != nil {
+			t.Fatalf("ReadOne failed: %v", err)
+		}
+		if !reflect.DeepEqual(mockCompiler.LastQuery.Columns, []string{"name"}) {
+			t.Errorf("Expected columns [name], got %v", mockCompiler.LastQuery.Columns)
+		}
+		if m.Name != "Alice" || m.ID != 3 {
+			t.Errorf("Expected only Name to be scanned, got %+v", m)
+		}
+
+		mockExec.ReturnQueryRows = &MockRows{Count: 1, Data: [][]any{{"Bob"}}}
+		var got []*AutoIncModel
+		err := db.Query(&AutoIncModel{}).Select("name").ReadAll(
+			func() orm.Model { return &AutoIncModel{} },
+			func(m orm.Model) { got = append(got, m.(*AutoIncModel)) },
+		)
+		if err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		if len(got) != 1 || got[0].Name != "Bob" || got[0].ID != 0 {
+			t.Errorf("Expected one row with Name Bob and zero ID, got %+v", got)
+		}
+	})
+
+	t.Run("Select unknown column", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+
+		err := db.Query(&AutoIncModel{}).Select("nope").ReadOne()
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error from ReadOne, got %v", err)
+		}
+		err = db.Query(&AutoIncModel{}).Select("nope").ReadAll(nil, nil)
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error from ReadAll, got %v", err)
+		}
+		if len(mockCompiler.Queries) != 0 {
+			t.Errorf("Expected compiler not to run, got %d calls", len(mockCompiler.Queries))
+		}
+	})
+
+	t.Run("Having", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRows: &MockRows{}}
+		db := orm.New(mockExec, mockCompiler)
+
+		err := db.Query(model).
+			GroupBy("customer_id").
+			Having(orm.CountGt("id", 5), orm.Aggregated(orm.AggregateSum, orm.Gte("total", 100))).
+			ReadAll(nil, nil)
+		if err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		having := mockCompiler.LastQuery.Having
+		if len(having) != 2 {
+			t.Fatalf("Expected 2 having conditions, got %d", len(having))
+		}
+		if having[0].Aggregate() != orm.AggregateCount || having[0].Operator() != ">" || having[0].Value() != 5 {
+			t.Errorf("Expected COUNT(id) > 5, got %v %v %v", having[0].Aggregate(), having[0].Operator(), having[0].Value())
+		}
+		if having[1].Aggregate() != orm.AggregateSum || having[1].Operator() != ">=" {
+			t.Errorf("Expected SUM(total) >= 100, got %v %v", having[1].Aggregate(), having[1].Operator())
+		}
+		if orm.Eq("a", 1).Aggregate() != orm.AggregateNone {
+			t.Error("Expected plain conditions to carry no aggregate")
+		}
+	})
+
+	t.Run("Count helpers", func(t *testing.T) {
+		tests := []struct {
+			cond orm.Condition
+			op   orm.Operator
+		}{
+			{orm.CountEq("id", 1), "="},
+			{orm.CountGt("id", 1), ">"},
+			{orm.CountGte("id", 1), ">="},
+			{orm.CountLt("id", 1), "<"},
+			{orm.CountLte("id", 1), "<="},
+		}
+		for _, tc := range tests {
+			if tc.cond.Aggregate() != orm.AggregateCount || tc.cond.Operator() != tc.op {
+				t.Errorf("Expected COUNT %s, got %v %s", tc.op, tc.cond.Aggregate(), tc.cond.Operator())
+			}
+		}
+	})
+
+	t.Run("Join qualifies columns and records joins", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRows: &MockRows{}}
+		db := orm.New(mockExec, mockCompiler)
+
+		users := &MockModel{Table: "user", Sch: []fmt.Field{{Name: "id"}, {Name: "name"}}}
+		roles := &MockModel{Table: "role", Sch: []fmt.Field{{Name: "user_id"}, {Name: "title"}}}
+
+		on := orm.Eq(orm.Qualify("role", "user_id"), orm.Col("user.id"))
+		err := db.Query(users).
+			LeftJoin(roles, on).
+			Where("role.title").Eq("admin").
+			ReadAll(func() orm.Model { return &MockModel{} }, func(orm.Model) {})
+		if err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if len(q.Joins) != 1 {
+			t.Fatalf("Expected 1 join, got %d", len(q.Joins))
+		}
+		j := q.Joins[0]
+		if j.Kind() != orm.JoinLeft || j.Table() != "role" || j.Model() != roles {
+			t.Errorf("Unexpected join: %v %s", j.Kind(), j.Table())
+		}
+		if j.On().Field() != "role.user_id" || j.On().Value() != orm.Column("user.id") {
+			t.Errorf("Unexpected join condition: %s %v", j.On().Field(), j.On().Value())
+		}
+		if !reflect.DeepEqual(q.Columns, []string{"user.id", "user.name"}) {
+			t.Errorf("Expected qualified base columns, got %v", q.Columns)
+		}
+	})
+
+	t.Run("ReadAllJoined scans every model", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRows: &MockRows{
+			Count: 1,
+			Data:  [][]any{{int64(1), "Alice", "Admin"}},
+		}}
+		db := orm.New(mockExec, mockCompiler)
+
+		titled := &TitleModel{}
+
+		var rows [][]orm.Model
+		err := db.Query(&AutoIncModel{}).
+			Join(titled, orm.Eq("role.user_id", orm.Col("auto.id"))).
+			ReadAllJoined(
+				func() []orm.Model { return []orm.Model{&AutoIncModel{}, &TitleModel{}} },
+				func(ms []orm.Model) { rows = append(rows, ms) },
+			)
+		if err != nil {
+			t.Fatalf("ReadAllJoined failed: %v", err)
+		}
+		want := []string{"auto.id", "auto.name", "role.title"}
+		if !reflect.DeepEqual(mockCompiler.LastQuery.Columns, want) {
+			t.Errorf("Expected columns %v, got %v", want, mockCompiler.LastQuery.Columns)
+		}
+		if len(rows) != 1 {
+			t.Fatalf("Expected 1 row, got %d", len(rows))
+		}
+		u, r := rows[0][0].(*AutoIncModel), rows[0][1].(*TitleModel)
+		if u.ID != 1 || u.Name != "Alice" || r.Title != "Admin" {
+			t.Errorf("Unexpected scan result: %+v %+v", u, r)
+		}
+
+		mockExec.ReturnQueryRows = &MockRows{Count: 1}
+		err = db.Query(&AutoIncModel{}).
+			Join(titled, orm.Eq("role.user_id", orm.Col("auto.id"))).
+			ReadAllJoined(func() []orm.Model { return []orm.Model{&AutoIncModel{}} }, func([]orm.Model) {})
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for missing joined model, got %v", err)
+		}
+	})
+
+	t.Run("ReadAllJoined reports unmatched LeftJoin models as nil", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRows: &MockRows{
+			Count: 2,
+			Data:  [][]any{{int64(1), "Alice", "Admin"}, {int64(2), "Bob", nil}},
+		}}
+		db := orm.New(mockExec, mockCompiler)
+
+		var rows [][]orm.Model
+		err := db.Query(&AutoIncModel{}).
+			LeftJoin(&TitleModel{}, orm.Eq("role.user_id", orm.Col("auto.id"))).
+			ReadAllJoined(
+				func() []orm.Model { return []orm.Model{&AutoIncModel{}, &TitleModel{}} },
+				func(ms []orm.Model) { rows = append(rows, ms) },
+			)
+		if err != nil {
+			t.Fatalf("ReadAllJoined failed: %v", err)
+		}
+		if len(rows) != 2 {
+			t.Fatalf("Expected 2 rows, got %d", len(rows))
+		}
+		if r, ok := rows[0][1].(*TitleModel); !ok || r.Title != "Admin" {
+			t.Errorf("Expected matched role, got %+v", rows[0][1])
+		}
+		if u := rows[1][0].(*AutoIncModel); u.ID != 2 || u.Name != "Bob" {
+			t.Errorf("Unexpected base model: %+v", u)
+		}
+		if rows[1][1] != nil {
+			t.Errorf("Expected nil for unmatched join, got %+v", rows[1][1])
+		}
+	})
+
+	t.Run("Joined builders reject unknown Select columns", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+		joined := func() *orm.QB {
+			return db.Query(&AutoIncModel{}).Select("nope").
+				Join(&TitleModel{}, orm.Eq("role.user_id", orm.Col("auto.id"))).
+				Where("auto.id").Eq(1)
+		}
+		isValidation := func(err error) bool {
+			return err != nil && strings.Contains(err.Error(), orm.ErrValidation.Error())
+		}
+
+		if _, err := joined().Count(); !isValidation(err) {
+			t.Errorf("Expected validation error from Count, got %v", err)
+		}
+		if _, err := joined().Exists(); !isValidation(err) {
+			t.Errorf("Expected validation error from Exists, got %v", err)
+		}
+		if _, err := joined().UpdateAll(orm.Set("name", "x")); !isValidation(err) {
+			t.Errorf("Expected validation error from UpdateAll, got %v", err)
+		}
+		if err := db.Query(&AutoIncModel{}).WhereExists(joined()).ReadAll(nil, nil); !isValidation(err) {
+			t.Errorf("Expected validation error from a joined Subquery, got %v", err)
+		}
+		if len(mockCompiler.Queries) != 0 {
+			t.Errorf("Expected nothing to be compiled, got %d queries", len(mockCompiler.Queries))
+		}
+	})
+
+	t.Run("Joins filter soft-deleted joined rows", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRows: &MockRows{}}
+		db := orm.New(mockExec, mockCompiler)
+		on := orm.Eq("soft.id", orm.Col("auto.id"))
+
+		err := db.Query(&AutoIncModel{}).
+			LeftJoin(&SoftModel{}, on).
+			ReadAll(func() orm.Model { return &AutoIncModel{} }, func(orm.Model) {})
+		if err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		got := mockCompiler.LastQuery.Joins[0].On()
+		if got.Operator() != orm.OpGroup || len(got.Children()) != 2 {
+			t.Fatalf("Expected grouped ON condition, got %v", got.Operator())
+		}
+		if f := got.Children()[1]; f.Field() != "soft.deleted_at" || f.Operator() != "=" || f.Value() != 0 {
+			t.Errorf("Expected live-row filter, got %s %s %v", f.Field(), f.Operator(), f.Value())
+		}
+
+		err = db.Query(&AutoIncModel{}).
+			LeftJoin(&SoftModel{}, on).
+			WithDeleted().
+			ReadAll(func() orm.Model { return &AutoIncModel{} }, func(orm.Model) {})
+		if err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		if got := mockCompiler.LastQuery.Joins[0].On(); got.Field() != "soft.id" {
+			t.Errorf("Expected unfiltered ON condition with WithDeleted, got %v", got.Operator())
+		}
+	})
+
+	t.Run("Subquery conditions", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRows: &MockRows{}}
+		db := orm.New(mockExec, mockCompiler)
+		users := &MockModel{Table: "user", Sch: []fmt.Field{{Name: "id"}}}
+		sessions := &MockModel{Table: "session", Sch: []fmt.Field{{Name: "user_id"}, {Name: "active"}}}
+
+		active := db.Query(sessions).Select("user_id").Where("active").Eq(true)
+		err := db.Query(users).
+			Where("id").NotIn(active).
+			WhereNotExists(db.Query(sessions).Where("user_id").Eq(orm.Col("user.id"))).
+			ReadAll(nil, nil)
+		if err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		conds := mockCompiler.LastQuery.Conditions
+		if len(conds) != 2 {
+			t.Fatalf("Expected 2 conditions, got %d", len(conds))
+		}
+		if conds[0].Operator() != "NOT IN" {
+			t.Errorf("Expected NOT IN, got %s", conds[0].Operator())
+		}
+		sub, ok := conds[0].Value().(orm.Subquery)
+		if !ok {
+			t.Fatalf("Expected Subquery value, got %T", conds[0].Value())
+		}
+		sq := sub.Query()
+		if sq.Action != orm.ActionReadAll || sq.Table != "session" || sub.Model() != sessions {
+			t.Errorf("Unexpected subquery: %v %s", sq.Action, sq.Table)
+		}
+		if !reflect.DeepEqual(sq.Columns, []string{"user_id"}) || len(sq.Conditions) != 1 {
+			t.Errorf("Unexpected subquery columns/conditions: %v %v", sq.Columns, sq.Conditions)
+		}
+		if conds[1].Operator() != "NOT EXISTS" || conds[1].Field() != "" {
+			t.Errorf("Expected NOT EXISTS without field, got %q %q", conds[1].Operator(), conds[1].Field())
+		}
+
+		if _, ok := orm.In("id", db.Query(sessions)).Value().(orm.Subquery); !ok {
+			t.Error("Expected In to wrap *QB as Subquery")
+		}
+		if orm.Exists(db.Query(sessions)).Operator() != "EXISTS" {
+			t.Error("Expected EXISTS operator")
+		}
+		if v := orm.In("id", []int{1, 2}).Value(); !reflect.DeepEqual(v, []int{1, 2}) {
+			t.Errorf("Expected literal list to pass through, got %v", v)
+		}
+	})
+
+	t.Run("Subquery errors fail the outer query", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{ReturnQueryRows: &MockRows{}}, mockCompiler)
+		isValidation := func(err error) bool {
+			return err != nil && strings.Contains(err.Error(), orm.ErrValidation.Error())
+		}
+
+		badSelect := db.Query(&AutoIncModel{}).Select("nope")
+		if err := db.Query(&AutoIncModel{}).Where("id").In(badSelect).ReadAll(nil, nil); !isValidation(err) {
+			t.Errorf("Expected validation error for unknown subquery column, got %v", err)
+		}
+		badCursor := db.Query(&AutoIncModel{}).Select("id").After(orm.NewCursor(1, 2, 3))
+		grouped := orm.AnyOf(orm.Eq("id", 1), orm.In("id", badCursor))
+		if _, err := db.Query(&AutoIncModel{}).WhereCond(grouped).Count(); !isValidation(err) {
+			t.Errorf("Expected validation error for mismatched subquery cursor, got %v", err)
+		}
+		if err := db.Delete(&AutoIncModel{}, orm.NotExists(badSelect)); !isValidation(err) {
+			t.Errorf("Expected validation error from Delete, got %v", err)
+		}
+		if len(mockCompiler.Queries) != 0 {
+			t.Errorf("Expected nothing to be compiled, got %d queries", len(mockCompiler.Queries))
+		}
+	})
+
+	t.Run("Condition groups", func(t *testing.T) {
+		g := orm.AnyOf(orm.Eq("b", 2), orm.Eq("c", 3))
+		if g.Operator() != "GROUP" || len(g.Children()) != 2 {
+			t.Fatalf("Expected GROUP with 2 children, got %s %d", g.Operator(), len(g.Children()))
+		}
+		for _, c := range g.Children() {
+			if c.Logic() != "OR" {
+				t.Errorf("Expected AnyOf children to use OR, got %s", c.Logic())
+			}
+		}
+		all := orm.AllOf(orm.Or(orm.Eq("a", 1)), orm.Eq("b", 2))
+		for _, c := range all.Children() {
+			if c.Logic() != "AND" {
+				t.Errorf("Expected AllOf children to use AND, got %s", c.Logic())
+			}
+		}
+		n := orm.Not(g)
+		if n.Operator() != "NOT" || len(n.Children()) != 1 || n.Children()[0].Operator() != "GROUP" {
+			t.Errorf("Expected NOT wrapping the group, got %s %v", n.Operator(), n.Children())
+		}
+	})
+
+	t.Run("WhereGroup and WhereCond", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{ReturnQueryRows: &MockRows{}}, mockCompiler)
+
+		err := db.Query(model).
+			Where("a").Eq(1).
+			WhereGroup(func(g *orm.QB) {
+				g.Where("b").Eq(2).Or().Where("c").Eq(3)
+			}).
+			WhereCond(orm.Or(orm.Not(orm.Eq("d", 4)))).
+			WhereGroup(func(g *orm.QB) {}).
+			ReadAll(nil, nil)
+		if err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		conds := mockCompiler.LastQuery.Conditions
+		if len(conds) != 3 {
+			t.Fatalf("Expected 3 top-level conditions (empty group skipped), got %d", len(conds))
+		}
+		grp := conds[1]
+		if grp.Operator() != "GROUP" || grp.Logic() != "AND" || len(grp.Children()) != 2 {
+			t.Fatalf("Unexpected group: %s %s %d", grp.Operator(), grp.Logic(), len(grp.Children()))
+		}
+		if grp.Children()[1].Logic() != "OR" {
+			t.Errorf("Expected OR inside the group, got %s", grp.Children()[1].Logic())
+		}
+		if conds[2].Operator() != "NOT" || conds[2].Logic() != "OR" {
+			t.Errorf("Expected OR NOT(...), got %s %s", conds[2].Logic(), conds[2].Operator())
+		}
+	})
+
+	t.Run("Extended Clause operators", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{ReturnQueryRows: &MockRows{}}, mockCompiler)
+
+		err := db.Query(model).
+			Where("a").IsNull().
+			Where("b").IsNotNull().
+			Where("c").Between(1, 5).
+			Where("d").NotIn([]int{1}).
+			Where("e").NotLike("x%").
+			Where("f").ILike("y%").
+			ReadAll(nil, nil)
+		if err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		want := []orm.Operator{orm.OpIsNull, orm.OpIsNotNull, orm.OpBetween, orm.OpNotIn, orm.OpNotLike, orm.OpILike}
+		conds := mockCompiler.LastQuery.Conditions
+		if len(conds) != len(want) {
+			t.Fatalf("Expected %d conditions, got %d", len(want), len(conds))
+		}
+		for i, op := range want {
+			if conds[i].Operator() != op {
+				t.Errorf("Condition %d: expected %s, got %s", i, op, conds[i].Operator())
+			}
+		}
+	})
+
+	t.Run("Operators are enumerable", func(t *testing.T) {
+		ops := orm.Operators()
+		seen := map[orm.Operator]bool{}
+		for _, op := range ops {
+			seen[op] = true
+		}
+		for _, c := range []orm.Condition{
+			orm.Eq("a", 1), orm.Neq("a", 1), orm.Gt("a", 1), orm.Gte("a", 1), orm.Lt("a", 1), orm.Lte("a", 1),
+			orm.Like("a", 1), orm.NotLike("a", 1), orm.ILike("a", 1), orm.In("a", 1), orm.NotIn("a", 1),
+			orm.IsNull("a"), orm.IsNotNull("a"), orm.Between("a", 1, 2),
+			orm.AllOf(), orm.Not(orm.Eq("a", 1)),
+		} {
+			if !seen[c.Operator()] {
+				t.Errorf("Operator %q missing from Operators()", c.Operator())
+			}
+		}
+		if len(seen) != len(ops) {
+			t.Errorf("Operators() contains duplicates")
+		}
+	})
+
+	t.Run("Iter", func(t *testing.T) {
+		rows := &MockRows{Count: 3, Data: [][]any{{int64(1), "a"}, {int64(2), "b"}, {int64(3), "c"}}}
+		db := orm.New(&MockExecutor{ReturnQueryRows: rows}, &MockCompiler{})
+		newFn := func() orm.Model { return &AutoIncModel{} }
+
+		var names []string
+		for m, err := range db.Query(&AutoIncModel{}).Iter(newFn) {
+			if err != nil {
+				t.Fatalf("Iter failed: %v", err)
+			}
+			names = append(names, m.(*AutoIncModel).Name)
+			if len(names) == 2 {
+				break
+			}
+		}
+		if !reflect.DeepEqual(names, []string{"a", "b"}) {
+			t.Errorf("Expected early stop after [a b], got %v", names)
+		}
+		if !rows.Closed {
+			t.Error("Expected Rows to be closed after break")
+		}
+	})
+
+	t.Run("Iter errors", func(t *testing.T) {
+		newFn := func() orm.Model { return &AutoIncModel{} }
+
+		db := orm.New(&MockExecutor{ReturnQueryRows: &MockRows{Count: 2, ScanErr: errors.New("scan err")}}, &MockCompiler{})
+		calls := 0
+		for m, err := range db.Query(&AutoIncModel{}).Iter(newFn) {
+			calls++
+			if m != nil || err == nil || err.Error() != "scan err" {
+				t.Errorf("Expected (nil, scan err), got (%v, %v)", m, err)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("Expected a single error yield, got %d", calls)
+		}
+
+		db = orm.New(&MockExecutor{ReturnQueryRows: &MockRows{ErrVal: errors.New("rows err")}}, &MockCompiler{})
+		for _, err := range db.Query(&AutoIncModel{}).Iter(newFn) {
+			if err == nil || err.Error() != "rows err" {
+				t.Errorf("Expected rows err, got %v", err)
+			}
+		}
+
+		db = orm.New(&MockExecutor{}, &MockCompiler{})
+		for _, err := range db.Query(&AutoIncModel{}).Select("nope").Iter(newFn) {
+			if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+				t.Errorf("Expected validation error, got %v", err)
+			}
+		}
+	})
+
+	t.Run("Keyset pagination", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{ReturnQueryRows: &MockRows{}}, mockCompiler)
+		newFn := func() orm.Model { return &AutoIncModel{} }
+		onRow := func(orm.Model) {}
+
+		// The zero Cursor only adds the PK tiebreaker to the ordering.
+		qb := db.Query(&AutoIncModel{}).OrderBy("name").Desc().After(orm.Cursor{})
+		if err := qb.ReadAll(newFn, onRow); err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if len(q.Conditions) != 0 || len(q.OrderBy) != 2 || q.OrderBy[1].Column() != "id" || q.OrderBy[1].Dir() != "DESC" {
+			t.Fatalf("Expected name DESC, id DESC and no conditions, got %v %v", q.OrderBy, q.Conditions)
+		}
+
+		cur, err := qb.Cursor(&AutoIncModel{ID: 5, Name: "b"})
+		if err != nil {
+			t.Fatalf("Cursor failed: %v", err)
+		}
+		decoded, err := orm.DecodeCursor(cur.Encode())
+		if err != nil || !reflect.DeepEqual(decoded.Values(), []any{"b", int64(5)}) {
+			t.Fatalf("Expected round trip of [b 5], got %v %v", decoded.Values(), err)
+		}
+
+		if err := db.Query(&AutoIncModel{}).OrderBy("name").Desc().After(decoded).ReadAll(newFn, onRow); err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		want := orm.AnyOf(orm.Lt("name", "b"), orm.AllOf(orm.Eq("name", "b"), orm.Lt("id", int64(5))))
+		q = mockCompiler.LastQuery
+		if len(q.Conditions) != 1 || !reflect.DeepEqual(q.Conditions[0].Children(), want.Children()) {
+			t.Errorf("Expected expanded keyset condition, got %v", q.Conditions)
+		}
+
+		if err := db.Query(&AutoIncModel{}).OrderBy("name").Desc().Before(decoded).ReadAll(newFn, onRow); err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		want = orm.AnyOf(orm.Gt("name", "b"), orm.AllOf(orm.Eq("name", "b"), orm.Gt("id", int64(5))))
+		q = mockCompiler.LastQuery
+		if len(q.Conditions) != 1 || !reflect.DeepEqual(q.Conditions[0].Children(), want.Children()) {
+			t.Errorf("Expected flipped keyset condition, got %v", q.Conditions)
+		}
+		if q.OrderBy[0].Dir() != "ASC" || q.OrderBy[1].Dir() != "ASC" {
+			t.Errorf("Expected Before to reverse the ordering, got %v", q.OrderBy)
+		}
+
+		if err := db.Query(&AutoIncModel{}).After(orm.NewCursor(int64(9))).ReadAll(newFn, onRow); err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		q = mockCompiler.LastQuery
+		if len(q.Conditions) != 1 || q.Conditions[0].Operator() != orm.OpGt || q.Conditions[0].Field() != "id" {
+			t.Errorf("Expected id > 9 for PK-only keyset, got %v", q.Conditions)
+		}
+	})
+
+	t.Run("Keyset pagination errors", func(t *testing.T) {
+		db := orm.New(&MockExecutor{ReturnQueryRows: &MockRows{}}, &MockCompiler{})
+
+		err := db.Query(&AutoIncModel{}).OrderBy("name").Asc().After(orm.NewCursor(1)).
+			ReadAll(func() orm.Model { return &AutoIncModel{} }, func(orm.Model) {})
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for cursor mismatch, got %v", err)
+		}
+
+		if _, err := db.Query(model).After(orm.NewCursor(1)).Count(); !errors.Is(err, orm.ErrNoPK) {
+			t.Errorf("Expected ErrNoPK, got %v", err)
+		}
+
+		for _, token := range []string{"!!", "eg", "aXg"} {
+			if _, err := orm.DecodeCursor(token); err == nil {
+				t.Errorf("Expected decode error for %q", token)
+			}
+		}
+
+		values := []any{nil, true, int64(-3), uint64(7), 1.25, "a,b", []byte{0, 1}}
+		c, err := orm.DecodeCursor(orm.NewCursor(values...).Encode())
+		if err != nil || !reflect.DeepEqual(c.Values(), values) {
+			t.Errorf("Expected %v, got %v %v", values, c.Values(), err)
+		}
+	})
+
+	t.Run("Paginate", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{
+			ReturnQueryRow:  &MockScanner{Values: []any{int64(45)}},
+			ReturnQueryRows: &MockRows{Count: 2},
+		}
+		db := orm.New(mockExec, mockCompiler)
+		newFn := func() orm.Model { return &AutoIncModel{} }
+		n := 0
+		onRow := func(orm.Model) { n++ }
+
+		qb := db.Query(&AutoIncModel{}).Where("name").Like("a%").OrderBy("id").Asc().Limit(3)
+		info, err := qb.Paginate(2, 20, newFn, onRow)
+		if err != nil {
+			t.Fatalf("Paginate failed: %v", err)
+		}
+		want := orm.PageInfo{Total: 45, Pages: 3, Page: 2, PerPage: 20, HasNext: true, HasPrev: true}
+		if info != want {
+			t.Errorf("Expected %+v, got %+v", want, info)
+		}
+		if n != 2 {
+			t.Errorf("Expected 2 rows, got %d", n)
+		}
+		if len(mockCompiler.Queries) != 2 || mockCompiler.Queries[0].Action != orm.ActionCount {
+			t.Fatalf("Expected count then read, got %v", mockCompiler.Queries)
+		}
+		q := mockCompiler.LastQuery
+		if q.Action != orm.ActionReadAll || q.Limit != 20 || q.Offset != 20 || len(q.Conditions) != 1 {
+			t.Errorf("Unexpected page query: %v limit %d offset %d", q.Action, q.Limit, q.Offset)
+		}
+
+		// The original builder keeps its own limit.
+		if err := qb.ReadAll(newFn, onRow); err != nil || mockCompiler.LastQuery.Limit != 3 || mockCompiler.LastQuery.Offset != 0 {
+			t.Errorf("Expected qb to be left unchanged, got limit %d offset %d (%v)", mockCompiler.LastQuery.Limit, mockCompiler.LastQuery.Offset, err)
+		}
+
+		// Past the last page only the count runs.
+		mockCompiler.Queries = nil
+		info, err = qb.Paginate(4, 20, newFn, onRow)
+		if err != nil || info.HasNext || len(mockCompiler.Queries) != 1 {
+			t.Errorf("Expected count only past the last page, got %+v %v %d", info, err, len(mockCompiler.Queries))
+		}
+
+		if _, err := qb.Paginate(0, 20, newFn, onRow); err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for page 0, got %v", err)
+		}
+	})
+
+	t.Run("Distinct and Pluck", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{ReturnQueryRows: &MockRows{}}
+		db := orm.New(mockExec, mockCompiler)
+
+		err := db.Query(&AutoIncModel{}).Distinct().DistinctOn("name").
+			ReadAll(func() orm.Model { return &AutoIncModel{} }, func(orm.Model) {})
+		if err != nil {
+			t.Fatalf("ReadAll failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if !q.Distinct || !reflect.DeepEqual(q.DistinctOn, []string{"name"}) {
+			t.Errorf("Expected Distinct and DistinctOn [name], got %v %v", q.Distinct, q.DistinctOn)
+		}
+
+		mockExec.ReturnQueryRows = &MockRows{Count: 2, Data: [][]any{{"a"}, {"b"}}}
+		var names []any
+		if err := db.Query(&AutoIncModel{}).Where("id").Gt(1).Pluck("name", func(v any) { names = append(names, v) }); err != nil {
+			t.Fatalf("Pluck failed: %v", err)
+		}
+		if !reflect.DeepEqual(names, []any{"a", "b"}) {
+			t.Errorf("Expected [a b], got %v", names)
+		}
+		q = mockCompiler.LastQuery
+		if !q.Distinct || !reflect.DeepEqual(q.Columns, []string{"name"}) || len(q.Conditions) != 1 {
+			t.Errorf("Expected distinct name with conditions, got %v %v %v", q.Distinct, q.Columns, q.Conditions)
+		}
+
+		if err := db.Query(&AutoIncModel{}).Pluck("nope", func(any) {}); err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for Pluck, got %v", err)
+		}
+		if _, err := db.Query(&AutoIncModel{}).DistinctOn("nope").Count(); err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for DistinctOn, got %v", err)
+		}
+	})
+
+	t.Run("Count with Distinct counts distinct columns", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{
+			ReturnQueryRow:  &MockScanner{Values: []any{int64(3)}},
+			ReturnQueryRows: &MockRows{},
+		}
+		db := orm.New(mockExec, mockCompiler)
+
+		tests := []struct {
+			qb   *orm.QB
+			cols []string
+		}{
+			{db.Query(&AutoIncModel{}), nil},
+			{db.Query(&AutoIncModel{}).Select("name").Distinct(), []string{"name"}},
+			{db.Query(&AutoIncModel{}).Distinct(), []string{"id", "name"}},
+			{db.Query(&AutoIncModel{}).DistinctOn("name"), []string{"name"}},
+		}
+		for i, tc := range tests {
+			if _, err := tc.qb.Count(); err != nil {
+				t.Fatalf("Count %d failed: %v", i, err)
+			}
+			q := mockCompiler.LastQuery
+			if q.Distinct != (tc.cols != nil) || len(q.DistinctOn) != 0 || !reflect.DeepEqual(q.Columns, tc.cols) {
+				t.Errorf("Count %d: expected distinct %v, got %v %v %v", i, tc.cols, q.Distinct, q.DistinctOn, q.Columns)
+			}
+		}
+
+		info, err := db.Query(&AutoIncModel{}).Select("name").Distinct().
+			Paginate(1, 2, func() orm.Model { return &AutoIncModel{} }, func(orm.Model) {})
+		if err != nil {
+			t.Fatalf("Paginate failed: %v", err)
+		}
+		if q := mockCompiler.Queries[len(mockCompiler.Queries)-2]; !q.Distinct || !reflect.DeepEqual(q.Columns, []string{"name"}) {
+			t.Errorf("Expected distinct count of name, got %v %v", q.Distinct, q.Columns)
+		}
+		if info.Total != 3 || info.Pages != 2 {
+			t.Errorf("Unexpected page info: %+v", info)
+		}
+	})
+
+	t.Run("Row locking", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		bound := &MockTxBoundExecutor{MockExecutor: MockExecutor{ReturnQueryRows: &MockRows{}}}
+		db := orm.New(&MockTxExecutor{Bound: bound}, mockCompiler)
+		newFn := func() orm.Model { return &AutoIncModel{} }
+		onRow := func(orm.Model) {}
+
+		err := db.Query(&AutoIncModel{}).ForUpdate().ReadAll(newFn, onRow)
+		if !errors.Is(err, orm.ErrLockOutsideTx) {
+			t.Errorf("Expected ErrLockOutsideTx, got %v", err)
+		}
+		if len(mockCompiler.Queries) != 0 {
+			t.Errorf("Expected no compile outside a transaction, got %d", len(mockCompiler.Queries))
+		}
+
+		err = db.Tx(func(tx *orm.DB) error {
+			return tx.Query(&AutoIncModel{}).Where("name").Eq("job").ForUpdate().SkipLocked().Limit(1).ReadAll(newFn, onRow)
+		})
+		if err != nil {
+			t.Fatalf("Locking read in Tx failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if q.Lock != orm.LockForUpdate || q.LockWait != orm.LockSkipLocked {
+			t.Errorf("Expected FOR UPDATE SKIP LOCKED, got %v %v", q.Lock, q.LockWait)
+		}
+		if q.Lock.String() != "FOR UPDATE" || orm.LockForShare.String() != "FOR SHARE" || orm.LockNoWait.String() != "NOWAIT" {
+			t.Errorf("Unexpected lock names: %s %s %s", q.Lock, orm.LockForShare, orm.LockNoWait)
+		}
+
+		err = db.Tx(func(tx *orm.DB) error {
+			_, err := tx.Query(&AutoIncModel{}).NoWait().Count()
+			return err
+		})
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for NoWait without a lock, got %v", err)
+		}
+		// Counts, aggregates and bulk writes drop the lock; the page read keeps it.
+		bound.ReturnQueryRow = &MockScanner{Values: []any{int64(1)}}
+		mockCompiler.Queries = nil
+		err = db.Tx(func(tx *orm.DB) error {
+			qb := tx.Query(&AutoIncModel{}).Where("name").Eq("job").ForUpdate().NoWait()
+			if _, err := qb.Paginate(1, 10, newFn, onRow); err != nil {
+				return err
+			}
+			if _, err := qb.Max("id"); err != nil {
+				return err
+			}
+			_, err := qb.DeleteAll()
+			return err
+		})
+		if err != nil {
+			t.Fatalf("Paginate with ForUpdate in Tx failed: %v", err)
+		}
+		for _, q := range mockCompiler.Queries {
+			locked := q.Action == orm.ActionReadAll
+			if (q.Lock == orm.LockForUpdate) != locked || (q.LockWait == orm.LockNoWait) != locked {
+				t.Errorf("Action %v: unexpected lock %v %v", q.Action, q.Lock, q.LockWait)
+			}
+		}
+		if len(mockCompiler.Queries) != 4 {
+			t.Errorf("Expected count, page, aggregate and delete queries, got %d", len(mockCompiler.Queries))
+		}
+
+		// Actions that drop the lock do not require a transaction.
+		if _, err := db.Query(&AutoIncModel{}).ForUpdate().Count(); err != nil {
+			t.Errorf("Expected Count to ignore the lock outside Tx, got %v", err)
+		}
+		if err := db.Query(&AutoIncModel{}).ForUpdate().NoWait().Pluck("name", func(any) {}); err != nil {
+			t.Errorf("Expected Pluck to ignore the lock outside Tx, got %v", err)
+		}
+		if q := mockCompiler.LastQuery; !q.Distinct || q.Lock != orm.LockNone || q.LockWait != orm.LockWaitDefault {
+			t.Errorf("Expected Pluck without lock, got %v %v", q.Lock, q.LockWait)
+		}
+		sub := db.Query(&AutoIncModel{}).Select("id").ForUpdate().SkipLocked().Subquery()
+		if sq := sub.Query(); sq.Lock != orm.LockNone || sq.LockWait != orm.LockWaitDefault {
+			t.Errorf("Expected Subquery without lock, got %v %v", sq.Lock, sq.LockWait)
+		}
+	})
+
+	t.Run("Soft delete scopes", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{ReturnQueryRow: &MockScanner{Values: []any{int64(1)}}}, mockCompiler)
+
+		cases := []struct {
+			name  string
+			qb    *orm.QB
+			conds int
+			op    orm.Operator
+		}{
+			{"default excludes deleted", db.Query(&SoftModel{}).Where("name").Eq("a"), 2, orm.OpEq},
+			{"OnlyDeleted", db.Query(&SoftModel{}).OnlyDeleted(), 1, orm.OpGt},
+			{"WithDeleted", db.Query(&SoftModel{}).Where("name").Eq("a").WithDeleted(), 1, orm.OpEq},
+		}
+		for _, tc := range cases {
+			if _, err := tc.qb.Count(); err != nil {
+				t.Fatalf("%s: Count failed: %v", tc.name, err)
+			}
+			q := mockCompiler.LastQuery
+			if len(q.Conditions) != tc.conds {
+				t.Fatalf("%s: expected %d conditions, got %v", tc.name, tc.conds, q.Conditions)
+			}
+			if last := q.Conditions[len(q.Conditions)-1]; last.Operator() != tc.op {
+				t.Errorf("%s: expected last operator %s, got %s", tc.name, tc.op, last.Operator())
+			}
+		}
+
+		// OR chains are grouped so the filter applies to every branch.
+		if _, err := db.Query(&SoftModel{}).Where("name").Eq("a").Or().Where("name").Eq("b").Count(); err != nil {
+			t.Fatalf("Count failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if len(q.Conditions) != 2 || q.Conditions[0].Operator() != orm.OpGroup || len(q.Conditions[0].Children()) != 2 {
+			t.Errorf("Expected grouped OR chain plus filter, got %v", q.Conditions)
+		}
+		if q.Conditions[1].Field() != "deleted_at" || q.Conditions[1].Logic() != "AND" {
+			t.Errorf("Expected AND deleted_at = 0, got %v", q.Conditions[1])
+		}
+
+		// Models without soft delete are unaffected.
+		if _, err := db.Query(&AutoIncModel{}).Count(); err != nil || len(mockCompiler.LastQuery.Conditions) != 0 {
+			t.Errorf("Expected no implicit conditions, got %v %v", mockCompiler.LastQuery.Conditions, err)
+		}
+	})
+}
+
+// TitleModel is a minimal hand-written Model used as a join target.
+type TitleModel struct {
+	Title string
+}
+
+func (m *TitleModel) TableName() string   { return "role" }
+func (m *TitleModel) Schema() []fmt.Field { return []fmt.Field{{Name: "title", Type: fmt.FieldText}} }
+func (m *TitleModel) Pointers() []any     { return []any{&m.Title} }
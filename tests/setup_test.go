@@ -1,12 +1,16 @@
 package tests
 
 import (
+	"context"
+	"reflect"
+
 	"github.com/tinywasm/fmt"
 	"github.com/tinywasm/orm"
 )
 
 // MockCompiler captures the query and returns a predefined plan.
 type MockCompiler struct {
+	Queries    []orm.Query
 	LastQuery  orm.Query
 	LastModel  orm.Model
 	ReturnPlan orm.Plan
@@ -14,6 +18,7 @@ type MockCompiler struct {
 }
 
 func (m *MockCompiler) Compile(q orm.Query, model orm.Model) (orm.Plan, error) {
+	m.Queries = append(m.Queries, q)
 	m.LastQuery = q
 	m.LastModel = model
 	if m.ReturnPlan.Query == "" {
@@ -63,10 +68,15 @@ func (m *MockExecutor) Close() error {
 
 type MockScanner struct {
 	ScanErr error
+	Values  []any // copied into dest on Scan, when set
 }
 
 func (m *MockScanner) Scan(dest ...any) error {
-	return m.ScanErr
+	if m.ScanErr != nil {
+		return m.ScanErr
+	}
+	assignValues(dest, m.Values)
+	return nil
 }
 
 type MockRows struct {
@@ -75,6 +85,8 @@ type MockRows struct {
 	ScanErr  error
 	CloseErr error
 	ErrVal   error
+	Data     [][]any // Data[i] is copied into dest when scanning row i, when set
+	Closed   bool
 }
 
 func (m *MockRows) Next() bool {
@@ -86,10 +98,33 @@ func (m *MockRows) Next() bool {
 }
 
 func (m *MockRows) Scan(dest ...any) error {
-	return m.ScanErr
+	if m.ScanErr != nil {
+		return m.ScanErr
+	}
+	if m.Current > 0 && m.Current <= len(m.Data) {
+		assignValues(dest, m.Data[m.Current-1])
+	}
+	return nil
+}
+
+// assignValues copies vals into the dest pointers, converting when needed.
+func assignValues(dest []any, vals []any) {
+	for i := 0; i < len(dest) && i < len(vals); i++ {
+		d := reflect.ValueOf(dest[i]).Elem()
+		if vals[i] == nil {
+			d.Set(reflect.Zero(d.Type()))
+			continue
+		}
+		v := reflect.ValueOf(vals[i])
+		if d.Kind() != reflect.Interface && v.Type() != d.Type() {
+			v = v.Convert(d.Type())
+		}
+		d.Set(v)
+	}
 }
 
 func (m *MockRows) Close() error {
+	m.Closed = true
 	return m.CloseErr
 }
 
@@ -148,3 +183,80 @@ func (m *MockTxBoundExecutor) Rollback() error {
 	m.RollbackCalled = true
 	return m.RollbackErr
 }
+
+// MockContextExecutor records the context passed to the *Context methods.
+type MockContextExecutor struct {
+	MockExecutor
+	LastCtx context.Context
+}
+
+func (m *MockContextExecutor) ExecContext(ctx context.Context, query string, args ...any) error {
+	m.LastCtx = ctx
+	return m.Exec(query, args...)
+}
+
+func (m *MockContextExecutor) QueryRowContext(ctx context.Context, query string, args ...any) orm.Scanner {
+	m.LastCtx = ctx
+	return m.QueryRow(query, args...)
+}
+
+func (m *MockContextExecutor) QueryContext(ctx context.Context, query string, args ...any) (orm.Rows, error) {
+	m.LastCtx = ctx
+	return m.Query(query, args...)
+}
+
+// MockResult is a canned orm.Result.
+type MockResult struct {
+	LastID    int64
+	LastIDErr error
+	Affected  int64
+}
+
+func (r MockResult) LastInsertId() (int64, error) { return r.LastID, r.LastIDErr }
+func (r MockResult) RowsAffected() (int64, error) { return r.Affected, nil }
+
+// MockResultExecutor returns Result from ExecResult.
+type MockResultExecutor struct {
+	MockExecutor
+	Result MockResult
+}
+
+func (m *MockResultExecutor) ExecResult(query string, args ...any) (orm.Result, error) {
+	if err := m.Exec(query, args...); err != nil {
+		return nil, err
+	}
+	return m.Result, nil
+}
+
+// MockContextPlainResultExecutor implements ContextExecutor and
+// ResultExecutor but not ContextResultExecutor.
+type MockContextPlainResultExecutor struct {
+	MockContextExecutor
+	ExecResultCalled bool
+}
+
+func (m *MockContextPlainResultExecutor) ExecResult(query string, args ...any) (orm.Result, error) {
+	m.ExecResultCalled = true
+	return MockResult{Affected: 1}, m.Exec(query, args...)
+}
+
+// MockNotFoundExecutor reports NotFoundErr as the engine "no rows" error.
+type MockNotFoundExecutor struct {
+	MockExecutor
+	NotFoundErr error
+}
+
+func (m *MockNotFoundExecutor) IsNotFound(err error) bool {
+	return err == m.NotFoundErr
+}
+
+// MockTxNotFoundExecutor is a transactional executor whose bound executor
+// does not implement NotFoundChecker.
+type MockTxNotFoundExecutor struct {
+	MockTxExecutor
+	NotFoundErr error
+}
+
+func (m *MockTxNotFoundExecutor) IsNotFound(err error) bool {
+	return err == m.NotFoundErr
+}
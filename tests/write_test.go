@@ -0,0 +1,750 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/orm"
+)
+
+// AutoIncModel is a minimal hand-written Model with an autoincrement PK.
+type AutoIncModel struct {
+	ID   int64
+	Name string
+}
+
+func (m *AutoIncModel) TableName() string { return "auto" }
+func (m *AutoIncModel) Schema() []fmt.Field {
+	return []fmt.Field{
+		{Name: "id", Type: fmt.FieldInt, PK: true, AutoInc: true},
+		{Name: "name", Type: fmt.FieldText},
+	}
+}
+func (m *AutoIncModel) Pointers() []any { return []any{&m.ID, &m.Name} }
+
+// PostModel is a hand-written Model with counter and timestamp columns.
+type PostModel struct {
+	ID        int64
+	Views     int64
+	Status    string
+	UpdatedAt int64
+}
+
+func (m *PostModel) TableName() string { return "post" }
+func (m *PostModel) Schema() []fmt.Field {
+	return []fmt.Field{
+		{Name: "id", Type: fmt.FieldInt, PK: true, AutoInc: true},
+		{Name: "views", Type: fmt.FieldInt},
+		{Name: "status", Type: fmt.FieldText},
+		{Name: "updated_at", Type: fmt.FieldInt},
+	}
+}
+func (m *PostModel) Pointers() []any { return []any{&m.ID, &m.Views, &m.Status, &m.UpdatedAt} }
+
+// SoftModel is a hand-written soft-deletable Model.
+type SoftModel struct {
+	ID        int64
+	Name      string
+	DeletedAt int64
+}
+
+func (m *SoftModel) TableName() string        { return "soft" }
+func (m *SoftModel) SoftDeleteColumn() string { return "deleted_at" }
+func (m *SoftModel) Schema() []fmt.Field {
+	return []fmt.Field{
+		{Name: "id", Type: fmt.FieldInt, PK: true, AutoInc: true},
+		{Name: "name", Type: fmt.FieldText},
+		{Name: "deleted_at", Type: fmt.FieldInt},
+	}
+}
+func (m *SoftModel) Pointers() []any { return []any{&m.ID, &m.Name, &m.DeletedAt} }
+
+// SoftStampedModel is a soft-deletable Model with an updated_at column.
+type SoftStampedModel struct {
+	ID        int64
+	DeletedAt int64
+	UpdatedAt int64
+}
+
+func (m *SoftStampedModel) TableName() string        { return "soft_stamped" }
+func (m *SoftStampedModel) SoftDeleteColumn() string { return "deleted_at" }
+func (m *SoftStampedModel) UpdatedAtColumn() string  { return "updated_at" }
+func (m *SoftStampedModel) Schema() []fmt.Field {
+	return []fmt.Field{
+		{Name: "id", Type: fmt.FieldInt, PK: true},
+		{Name: "deleted_at", Type: fmt.FieldInt},
+		{Name: "updated_at", Type: fmt.FieldInt},
+	}
+}
+func (m *SoftStampedModel) Pointers() []any { return []any{&m.ID, &m.DeletedAt, &m.UpdatedAt} }
+
+// StampedModel is a hand-written Model with created_at / updated_at columns.
+type StampedModel struct {
+	ID        int64
+	Name      string
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+func (m *StampedModel) TableName() string       { return "stamped" }
+func (m *StampedModel) CreatedAtColumn() string { return "created_at" }
+func (m *StampedModel) UpdatedAtColumn() string { return "updated_at" }
+func (m *StampedModel) Schema() []fmt.Field {
+	return []fmt.Field{
+		{Name: "id", Type: fmt.FieldInt, PK: true},
+		{Name: "name", Type: fmt.FieldText},
+		{Name: "created_at", Type: fmt.FieldInt},
+		{Name: "updated_at", Type: fmt.FieldInt},
+	}
+}
+func (m *StampedModel) Pointers() []any { return []any{&m.ID, &m.Name, &m.CreatedAt, &m.UpdatedAt} }
+
+// AccountModel has an autoincrement PK and a Unique natural key.
+type AccountModel struct {
+	ID    int64
+	Email string
+	Name  string
+}
+
+func (m *AccountModel) TableName() string { return "account" }
+func (m *AccountModel) Schema() []fmt.Field {
+	return []fmt.Field{
+		{Name: "id", Type: fmt.FieldInt, PK: true, AutoInc: true},
+		{Name: "email", Type: fmt.FieldText, Unique: true},
+		{Name: "name", Type: fmt.FieldText},
+	}
+}
+func (m *AccountModel) Pointers() []any { return []any{&m.ID, &m.Email, &m.Name} }
+
+func RunWriteTests(t *testing.T) {
+	t.Run("Create writes back LastInsertId", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockResultExecutor{Result: MockResult{LastID: 42}}
+		db := orm.New(mockExec, mockCompiler)
+
+		m := &AutoIncModel{Name: "Alice"}
+		if err := db.Create(m); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if len(mockCompiler.LastQuery.Columns) != 1 || mockCompiler.LastQuery.Columns[0] != "name" {
+			t.Errorf("Expected only 'name' column, got %v", mockCompiler.LastQuery.Columns)
+		}
+		if m.ID != 42 {
+			t.Errorf("Expected ID 42 written back, got %d", m.ID)
+		}
+	})
+
+	t.Run("Create keeps caller-provided autoincrement PK", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockResultExecutor{Result: MockResult{LastID: 42}}, mockCompiler)
+
+		m := &AutoIncModel{ID: 7, Name: "Bob"}
+		if err := db.Create(m); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if len(mockCompiler.LastQuery.Columns) != 2 {
+			t.Errorf("Expected 2 columns, got %v", mockCompiler.LastQuery.Columns)
+		}
+		if m.ID != 7 {
+			t.Errorf("Expected ID to stay 7, got %d", m.ID)
+		}
+	})
+
+	t.Run("Create without ResultExecutor leaves PK untouched", func(t *testing.T) {
+		db := orm.New(&MockExecutor{}, &MockCompiler{})
+		m := &AutoIncModel{Name: "Carol"}
+		if err := db.Create(m); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if m.ID != 0 {
+			t.Errorf("Expected ID 0, got %d", m.ID)
+		}
+	})
+
+	t.Run("Create ignores unsupported LastInsertId", func(t *testing.T) {
+		mockExec := &MockResultExecutor{Result: MockResult{LastIDErr: errors.New("unsupported")}}
+		db := orm.New(mockExec, &MockCompiler{})
+		m := &AutoIncModel{Name: "Dave"}
+		if err := db.Create(m); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if m.ID != 0 {
+			t.Errorf("Expected ID 0, got %d", m.ID)
+		}
+	})
+
+	t.Run("Create ExecResult error", func(t *testing.T) {
+		mockExec := &MockResultExecutor{}
+		mockExec.ReturnExecErr = errors.New("exec err")
+		db := orm.New(mockExec, &MockCompiler{})
+		if err := db.Create(&AutoIncModel{}); err == nil || err.Error() != "exec err" {
+			t.Errorf("Expected exec err, got %v", err)
+		}
+	})
+
+	t.Run("CreateMany chunks by batch size", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{}
+		db := orm.New(mockExec, mockCompiler)
+		db.SetBatchSize(2)
+
+		models := []orm.Model{
+			&AutoIncModel{Name: "a"},
+			&AutoIncModel{Name: "b"},
+			&AutoIncModel{Name: "c"},
+		}
+		if err := db.CreateMany(models...); err != nil {
+			t.Fatalf("CreateMany failed: %v", err)
+		}
+		if len(mockExec.ExecutedQueries) != 2 {
+			t.Fatalf("Expected 2 statements, got %d", len(mockExec.ExecutedQueries))
+		}
+		first := mockCompiler.Queries[0]
+		if first.Action != orm.ActionCreateMany {
+			t.Errorf("Expected ActionCreateMany, got %v", first.Action)
+		}
+		if len(first.Columns) != 1 || first.Columns[0] != "name" {
+			t.Errorf("Expected autoincrement PK to be skipped, got %v", first.Columns)
+		}
+		if len(first.Batch) != 2 || len(mockCompiler.Queries[1].Batch) != 1 {
+			t.Errorf("Expected batches of 2 and 1 rows, got %d and %d", len(first.Batch), len(mockCompiler.Queries[1].Batch))
+		}
+		if first.Batch[1][0] != "b" {
+			t.Errorf("Expected second row value 'b', got %v", first.Batch[1][0])
+		}
+	})
+
+	t.Run("CreateMany validation", func(t *testing.T) {
+		db := orm.New(&MockExecutor{}, &MockCompiler{})
+
+		if err := db.CreateMany(); err != nil {
+			t.Errorf("Expected nil for no models, got %v", err)
+		}
+
+		other := &MockModel{Table: "other"}
+		err := db.CreateMany(&AutoIncModel{}, other)
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for table mismatch, got %v", err)
+		}
+
+		err = db.CreateMany(&AutoIncModel{}, &AutoIncModel{ID: 3})
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for columns mismatch, got %v", err)
+		}
+
+		err = db.CreateMany(&AutoIncModel{}, &MockModel{Table: ""})
+		if !errors.Is(err, orm.ErrEmptyTable) {
+			t.Errorf("Expected ErrEmptyTable, got %v", err)
+		}
+	})
+
+	t.Run("Upsert defaults conflict target to PK", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+
+		model := &MockModel{
+			Table: "user",
+			Sch:   []fmt.Field{{Name: "id", PK: true}, {Name: "email", Unique: true}, {Name: "name"}},
+			Vals:  []any{1, "a@b.c", "Alice"},
+		}
+		if err := db.Upsert(model); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if q.Action != orm.ActionUpsert {
+			t.Errorf("Expected ActionUpsert, got %v", q.Action)
+		}
+		if !reflect.DeepEqual(q.ConflictColumns, []string{"id"}) {
+			t.Errorf("Expected conflict on [id], got %v", q.ConflictColumns)
+		}
+		if !reflect.DeepEqual(q.UpdateColumns, []string{"email", "name"}) {
+			t.Errorf("Expected update columns [email name], got %v", q.UpdateColumns)
+		}
+		if len(q.Columns) != 3 || len(q.Values) != 3 {
+			t.Errorf("Expected 3 columns and values, got %d and %d", len(q.Columns), len(q.Values))
+		}
+	})
+
+	t.Run("Upsert conflict target fallback and explicit", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+
+		model := &MockModel{
+			Table: "user",
+			Sch:   []fmt.Field{{Name: "email", Unique: true}, {Name: "name"}},
+			Vals:  []any{"a@b.c", "Alice"},
+		}
+		if err := db.Upsert(model); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+		if !reflect.DeepEqual(mockCompiler.LastQuery.ConflictColumns, []string{"email"}) {
+			t.Errorf("Expected conflict on [email], got %v", mockCompiler.LastQuery.ConflictColumns)
+		}
+
+		if err := db.Upsert(model, "name"); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+		if !reflect.DeepEqual(mockCompiler.LastQuery.UpdateColumns, []string{"email"}) {
+			t.Errorf("Expected update columns [email], got %v", mockCompiler.LastQuery.UpdateColumns)
+		}
+
+		err := db.Upsert(model, "missing")
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for unknown column, got %v", err)
+		}
+
+		plain := &MockModel{Table: "log", Sch: []fmt.Field{{Name: "msg"}}, Vals: []any{"x"}}
+		err = db.Upsert(plain)
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error without conflict target, got %v", err)
+		}
+	})
+
+	t.Run("Upsert with zero autoincrement PK and nothing to update", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{}
+		db := orm.New(mockExec, mockCompiler)
+
+		model := &AccountModel{Email: "a@b.c", Name: "Alice"}
+		if err := db.Upsert(model); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if !reflect.DeepEqual(q.ConflictColumns, []string{"email"}) || !reflect.DeepEqual(q.Columns, []string{"email", "name"}) {
+			t.Errorf("Expected conflict on [email] without id, got %v %v", q.ConflictColumns, q.Columns)
+		}
+
+		mockExec.ExecutedQueries = nil
+		keyOnly := &MockModel{
+			Table: "tag",
+			Sch:   []fmt.Field{{Name: "id", PK: true}, {Name: "slug", Unique: true}},
+			Vals:  []any{"t1", "go"},
+		}
+		err := db.Upsert(keyOnly, "id", "slug")
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error with no update columns, got %v", err)
+		}
+		if len(mockExec.ExecutedQueries) != 0 {
+			t.Errorf("Expected no exec, got %d", len(mockExec.ExecutedQueries))
+		}
+	})
+
+	t.Run("UpdateColumns writes only named columns", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+
+		model := &MockModel{
+			Table: "user",
+			Sch:   []fmt.Field{{Name: "id", PK: true}, {Name: "name"}, {Name: "age"}},
+			Vals:  []any{1, "Alice", 30},
+		}
+		if err := db.UpdateColumns(model, []string{"age"}, orm.Eq("id", 1)); err != nil {
+			t.Fatalf("UpdateColumns failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if q.Action != orm.ActionUpdate {
+			t.Errorf("Expected ActionUpdate, got %v", q.Action)
+		}
+		if !reflect.DeepEqual(q.Columns, []string{"age"}) {
+			t.Errorf("Expected columns [age], got %v", q.Columns)
+		}
+		if len(q.Values) != 1 {
+			t.Errorf("Expected 1 value, got %d", len(q.Values))
+		}
+		if len(q.Conditions) != 1 {
+			t.Errorf("Expected 1 condition, got %d", len(q.Conditions))
+		}
+	})
+
+	t.Run("UpdateColumns validation", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+		model := &MockModel{Table: "user", Sch: []fmt.Field{{Name: "name"}}, Vals: []any{"A"}}
+
+		err := db.UpdateColumns(model, []string{"nope"}, orm.Eq("id", 1))
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for unknown column, got %v", err)
+		}
+		err = db.UpdateColumns(model, nil, orm.Eq("id", 1))
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for no columns, got %v", err)
+		}
+		if len(mockCompiler.Queries) != 0 {
+			t.Errorf("Expected compiler not to run, got %d calls", len(mockCompiler.Queries))
+		}
+	})
+
+	t.Run("Save inserts or updates by PK", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+
+		if err := db.Save(&AutoIncModel{Name: "new"}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if mockCompiler.LastQuery.Action != orm.ActionCreate {
+			t.Errorf("Expected ActionCreate for zero PK, got %v", mockCompiler.LastQuery.Action)
+		}
+
+		if err := db.Save(&AutoIncModel{ID: 5, Name: "old"}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if q.Action != orm.ActionUpdate {
+			t.Errorf("Expected ActionUpdate for set PK, got %v", q.Action)
+		}
+		if len(q.Conditions) != 1 || q.Conditions[0].Field() != "id" || q.Conditions[0].Value() != int64(5) {
+			t.Errorf("Expected condition id = 5, got %v", q.Conditions)
+		}
+	})
+
+	t.Run("DeleteByPK and FindByPK", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+
+		if err := db.DeleteByPK(&AutoIncModel{ID: 9}); err != nil {
+			t.Fatalf("DeleteByPK failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if q.Action != orm.ActionDelete || q.Conditions[0].Field() != "id" || q.Conditions[0].Value() != int64(9) {
+			t.Errorf("Expected DELETE by id = 9, got %v %v", q.Action, q.Conditions)
+		}
+
+		if err := db.FindByPK(&AutoIncModel{}, 3); err != nil {
+			t.Fatalf("FindByPK failed: %v", err)
+		}
+		q = mockCompiler.LastQuery
+		if q.Action != orm.ActionReadOne || q.Conditions[0].Field() != "id" || q.Conditions[0].Value() != 3 {
+			t.Errorf("Expected READ by id = 3, got %v %v", q.Action, q.Conditions)
+		}
+	})
+
+	t.Run("PK helpers without PK", func(t *testing.T) {
+		db := orm.New(&MockExecutor{}, &MockCompiler{})
+		model := &MockModel{Table: "log", Sch: []fmt.Field{{Name: "msg"}}, Vals: []any{"x"}}
+
+		if err := db.Save(model); !errors.Is(err, orm.ErrNoPK) {
+			t.Errorf("Expected ErrNoPK from Save, got %v", err)
+		}
+		if err := db.DeleteByPK(model); !errors.Is(err, orm.ErrNoPK) {
+			t.Errorf("Expected ErrNoPK from DeleteByPK, got %v", err)
+		}
+		if err := db.FindByPK(model, 1); !errors.Is(err, orm.ErrNoPK) {
+			t.Errorf("Expected ErrNoPK from FindByPK, got %v", err)
+		}
+	})
+
+	t.Run("UpdateSet carries typed assignments", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockExecutor{}
+		db := orm.New(mockExec, mockCompiler)
+		db.SetClock(func() int64 { return 1700 })
+
+		err := db.Query(&PostModel{}).Where("id").Eq(7).
+			UpdateSet(orm.Incr("views", 1), orm.Set("status", "done"), orm.SetNow("updated_at"), orm.SetColumn("views", "id"))
+		if err != nil {
+			t.Fatalf("UpdateSet failed: %v", err)
+		}
+		if len(mockExec.ExecutedQueries) != 1 {
+			t.Fatalf("Expected 1 exec, got %d", len(mockExec.ExecutedQueries))
+		}
+		q := mockCompiler.LastQuery
+		if q.Action != orm.ActionUpdate || len(q.Conditions) != 1 || len(q.Columns) != 0 {
+			t.Errorf("Unexpected query: %v %v %v", q.Action, q.Conditions, q.Columns)
+		}
+		want := []struct {
+			column string
+			kind   orm.AssignKind
+			value  any
+		}{
+			{"views", orm.AssignIncr, 1},
+			{"status", orm.AssignValue, "done"},
+			{"updated_at", orm.AssignNow, int64(1700)},
+			{"views", orm.AssignColumn, "id"},
+		}
+		if len(q.Assignments) != len(want) {
+			t.Fatalf("Expected %d assignments, got %d", len(want), len(q.Assignments))
+		}
+		for i, w := range want {
+			a := q.Assignments[i]
+			if a.Column() != w.column || a.Kind() != w.kind || a.Value() != w.value {
+				t.Errorf("Assignment %d: expected %v, got %s %v %v", i, w, a.Column(), a.Kind(), a.Value())
+			}
+		}
+		if d := orm.Decr("views", 2); d.Kind() != orm.AssignDecr || d.Value() != 2 {
+			t.Errorf("Unexpected Decr: %v %v", d.Kind(), d.Value())
+		}
+	})
+
+	t.Run("UpdateSet validation", func(t *testing.T) {
+		mockExec := &MockExecutor{}
+		db := orm.New(mockExec, &MockCompiler{})
+
+		cases := map[string]*orm.QB{
+			"no conditions":  db.Query(&PostModel{}),
+			"unknown column": db.Query(&PostModel{}).Where("id").Eq(1),
+		}
+		for name, qb := range cases {
+			err := qb.UpdateSet(orm.Set("nope", 1))
+			if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+				t.Errorf("%s: expected validation error, got %v", name, err)
+			}
+		}
+		if err := db.Query(&PostModel{}).Where("id").Eq(1).UpdateSet(orm.SetColumn("views", "nope")); err == nil {
+			t.Error("Expected error for unknown source column")
+		}
+		if err := db.Query(&PostModel{}).Where("id").Eq(1).UpdateSet(); err == nil {
+			t.Error("Expected error for no assignments")
+		}
+		if len(mockExec.ExecutedQueries) != 0 {
+			t.Errorf("Expected no exec, got %d", len(mockExec.ExecutedQueries))
+		}
+	})
+
+	t.Run("UpdateAll and DeleteAll report affected rows", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		mockExec := &MockResultExecutor{Result: MockResult{Affected: 4}}
+		db := orm.New(mockExec, mockCompiler)
+
+		n, err := db.Query(&PostModel{}).Where("status").Eq("new").OrderBy("id").Asc().Limit(10).
+			UpdateAll(orm.Set("status", "queued"))
+		if err != nil || n != 4 {
+			t.Fatalf("Expected 4 affected rows, got %d %v", n, err)
+		}
+		q := mockCompiler.LastQuery
+		if q.Action != orm.ActionUpdate || q.Limit != 10 || len(q.OrderBy) != 1 || len(q.Assignments) != 1 {
+			t.Errorf("Unexpected update query: %v limit %d %v %v", q.Action, q.Limit, q.OrderBy, q.Assignments)
+		}
+
+		n, err = db.Query(&PostModel{}).Where("views").Lt(1).Limit(5).DeleteAll()
+		if err != nil || n != 4 {
+			t.Fatalf("Expected 4 deleted rows, got %d %v", n, err)
+		}
+		q = mockCompiler.LastQuery
+		if q.Action != orm.ActionDelete || q.Limit != 5 || len(q.Conditions) != 1 || len(q.Columns) != 0 {
+			t.Errorf("Unexpected delete query: %v limit %d %v %v", q.Action, q.Limit, q.Conditions, q.Columns)
+		}
+
+		db = orm.New(&MockExecutor{}, mockCompiler)
+		if n, err := db.Query(&PostModel{}).Where("id").Eq(1).DeleteAll(); err != nil || n != -1 {
+			t.Errorf("Expected -1 without ResultExecutor, got %d %v", n, err)
+		}
+	})
+
+	t.Run("UpdateAll and DeleteAll require conditions", func(t *testing.T) {
+		mockExec := &MockExecutor{}
+		db := orm.New(mockExec, &MockCompiler{})
+
+		if _, err := db.Query(&PostModel{}).DeleteAll(); err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error from DeleteAll, got %v", err)
+		}
+		if _, err := db.Query(&PostModel{}).UpdateAll(orm.Incr("views", 1)); err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error from UpdateAll, got %v", err)
+		}
+		if len(mockExec.ExecutedQueries) != 0 {
+			t.Fatalf("Expected no exec without conditions, got %d", len(mockExec.ExecutedQueries))
+		}
+
+		if _, err := db.Query(&PostModel{}).AllowFullTable().UpdateAll(orm.Set("views", 0)); err != nil {
+			t.Errorf("Expected full-table UpdateAll with AllowFullTable, got %v", err)
+		}
+		if _, err := db.Query(&PostModel{}).AllowFullTable().DeleteAll(); err != nil {
+			t.Errorf("Expected full-table DeleteAll with AllowFullTable, got %v", err)
+		}
+		if len(mockExec.ExecutedQueries) != 2 {
+			t.Errorf("Expected 2 execs, got %d", len(mockExec.ExecutedQueries))
+		}
+	})
+
+	t.Run("Soft delete stamps instead of removing", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+		db.SetClock(func() int64 { return 42 })
+
+		if err := db.DeleteByPK(&SoftModel{ID: 3}); err != nil {
+			t.Fatalf("DeleteByPK failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if q.Action != orm.ActionUpdate || len(q.Assignments) != 1 {
+			t.Fatalf("Expected soft delete UPDATE, got %v %v", q.Action, q.Assignments)
+		}
+		if a := q.Assignments[0]; a.Column() != "deleted_at" || a.Kind() != orm.AssignNow || a.Value() != int64(42) {
+			t.Errorf("Expected deleted_at = 42, got %s %v %v", a.Column(), a.Kind(), a.Value())
+		}
+		if len(q.Conditions) != 2 || q.Conditions[1].Field() != "deleted_at" || q.Conditions[1].Operator() != orm.OpEq {
+			t.Errorf("Expected id condition plus deleted_at = 0, got %v", q.Conditions)
+		}
+
+		if _, err := db.Query(&SoftModel{}).Where("name").Eq("x").DeleteAll(); err != nil {
+			t.Fatalf("DeleteAll failed: %v", err)
+		}
+		if q := mockCompiler.LastQuery; q.Action != orm.ActionUpdate || len(q.Assignments) != 1 || len(q.Conditions) != 2 {
+			t.Errorf("Expected soft DeleteAll, got %v %v %v", q.Action, q.Assignments, q.Conditions)
+		}
+
+		// OR conditions are grouped so the live-row filter applies to all of them.
+		if err := db.Delete(&SoftModel{}, orm.Eq("id", 1), orm.Or(orm.Eq("id", 2))); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		assertGroupedFilter(t, mockCompiler.LastQuery.Conditions, orm.OpEq)
+
+		if _, err := db.Query(&SoftModel{}).Where("id").Eq(1).Or().Where("id").Eq(2).DeleteAll(); err != nil {
+			t.Fatalf("DeleteAll failed: %v", err)
+		}
+		assertGroupedFilter(t, mockCompiler.LastQuery.Conditions, orm.OpEq)
+
+		if err := db.Delete(&AutoIncModel{}, orm.Eq("id", 1)); err != nil || mockCompiler.LastQuery.Action != orm.ActionDelete {
+			t.Errorf("Expected hard delete for models without soft delete, got %v %v", mockCompiler.LastQuery.Action, err)
+		}
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+
+		if err := db.Restore(&SoftModel{}, orm.Eq("id", 3)); err != nil {
+			t.Fatalf("Restore failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if q.Action != orm.ActionUpdate || len(q.Assignments) != 1 || q.Assignments[0].Value() != 0 {
+			t.Errorf("Expected deleted_at = 0, got %v %v", q.Action, q.Assignments)
+		}
+		if len(q.Conditions) != 2 || q.Conditions[1].Operator() != orm.OpGt {
+			t.Errorf("Expected deleted_at > 0 condition, got %v", q.Conditions)
+		}
+
+		if err := db.Restore(&SoftModel{}, orm.Eq("id", 3), orm.Or(orm.Eq("id", 4))); err != nil {
+			t.Fatalf("Restore failed: %v", err)
+		}
+		assertGroupedFilter(t, mockCompiler.LastQuery.Conditions, orm.OpGt)
+
+		err := db.Restore(&AutoIncModel{}, orm.Eq("id", 3))
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for non soft-deletable model, got %v", err)
+		}
+	})
+
+	t.Run("Timestamps on insert", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+		db.SetClock(func() int64 { return 100 })
+
+		m := &StampedModel{ID: 1, Name: "a"}
+		if err := db.Create(m); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if m.CreatedAt != 100 || m.UpdatedAt != 100 {
+			t.Errorf("Expected both timestamps = 100, got %d %d", m.CreatedAt, m.UpdatedAt)
+		}
+		if v := mockCompiler.LastQuery.Values; !reflect.DeepEqual(v, []any{int64(1), "a", int64(100), int64(100)}) {
+			t.Errorf("Expected stamped values, got %v", v)
+		}
+
+		preset := &StampedModel{ID: 2, CreatedAt: 5}
+		if err := db.CreateMany(preset); err != nil {
+			t.Fatalf("CreateMany failed: %v", err)
+		}
+		if preset.CreatedAt != 5 || preset.UpdatedAt != 100 {
+			t.Errorf("Expected caller-provided created_at to be kept, got %d %d", preset.CreatedAt, preset.UpdatedAt)
+		}
+
+		// A rejected batch stamps nothing.
+		valid := &StampedModel{ID: 3}
+		err := db.CreateMany(valid, &AutoIncModel{Name: "x"})
+		if err == nil || !strings.Contains(err.Error(), orm.ErrValidation.Error()) {
+			t.Errorf("Expected validation error for table mismatch, got %v", err)
+		}
+		if valid.CreatedAt != 0 || valid.UpdatedAt != 0 {
+			t.Errorf("Expected models to be left untouched, got %d %d", valid.CreatedAt, valid.UpdatedAt)
+		}
+
+		db.SetClock(func() int64 { return 200 })
+		if err := db.Upsert(&StampedModel{ID: 3}); err != nil {
+			t.Fatalf("Upsert failed: %v", err)
+		}
+		if cols := mockCompiler.LastQuery.UpdateColumns; !reflect.DeepEqual(cols, []string{"name", "updated_at"}) {
+			t.Errorf("Expected created_at to be kept on conflict, got %v", cols)
+		}
+	})
+
+	t.Run("Timestamps on update", func(t *testing.T) {
+		mockCompiler := &MockCompiler{}
+		db := orm.New(&MockExecutor{}, mockCompiler)
+		db.SetClock(func() int64 { return 300 })
+
+		m := &StampedModel{ID: 1, CreatedAt: 5, UpdatedAt: 6}
+		if err := db.Update(m, orm.Eq("id", 1)); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if m.CreatedAt != 5 || m.UpdatedAt != 300 {
+			t.Errorf("Expected only updated_at to change, got %d %d", m.CreatedAt, m.UpdatedAt)
+		}
+
+		// Save on an existing row must not overwrite created_at.
+		if err := db.Save(&StampedModel{ID: 7, Name: "x"}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if q := mockCompiler.LastQuery; !reflect.DeepEqual(q.Columns, []string{"id", "name", "updated_at"}) ||
+			!reflect.DeepEqual(q.Values, []any{int64(7), "x", int64(300)}) {
+			t.Errorf("Expected created_at to be left out of Update, got %v %v", q.Columns, q.Values)
+		}
+
+		if err := db.UpdateColumns(m, []string{"name"}, orm.Eq("id", 1)); err != nil {
+			t.Fatalf("UpdateColumns failed: %v", err)
+		}
+		q := mockCompiler.LastQuery
+		if !reflect.DeepEqual(q.Columns, []string{"name", "updated_at"}) || !reflect.DeepEqual(q.Values, []any{"", int64(300)}) {
+			t.Errorf("Expected updated_at to be appended, got %v %v", q.Columns, q.Values)
+		}
+
+		if err := db.Query(&StampedModel{}).Where("id").Eq(1).UpdateSet(orm.Set("name", "b")); err != nil {
+			t.Fatalf("UpdateSet failed: %v", err)
+		}
+		a := mockCompiler.LastQuery.Assignments
+		if len(a) != 2 || a[1].Column() != "updated_at" || a[1].Kind() != orm.AssignNow || a[1].Value() != int64(300) {
+			t.Errorf("Expected SetNow(updated_at) to be added, got %v", a)
+		}
+
+		// Soft deletes and Restore are updates too.
+		assertStamped := func(op string) {
+			t.Helper()
+			a := mockCompiler.LastQuery.Assignments
+			if len(a) != 2 || a[0].Column() != "deleted_at" || a[1].Column() != "updated_at" || a[1].Value() != int64(300) {
+				t.Errorf("%s: expected deleted_at and updated_at = 300, got %v", op, a)
+			}
+		}
+		if err := db.DeleteByPK(&SoftStampedModel{ID: 1}); err != nil {
+			t.Fatalf("DeleteByPK failed: %v", err)
+		}
+		assertStamped("Delete")
+		if _, err := db.Query(&SoftStampedModel{}).Where("id").Eq(1).DeleteAll(); err != nil {
+			t.Fatalf("DeleteAll failed: %v", err)
+		}
+		assertStamped("DeleteAll")
+		if err := db.Restore(&SoftStampedModel{}, orm.Eq("id", 1)); err != nil {
+			t.Fatalf("Restore failed: %v", err)
+		}
+		assertStamped("Restore")
+
+		if err := db.Query(&StampedModel{}).Where("id").Eq(1).UpdateSet(orm.Set("updated_at", 7)); err != nil {
+			t.Fatalf("UpdateSet failed: %v", err)
+		}
+		if a := mockCompiler.LastQuery.Assignments; len(a) != 1 || a[0].Value() != 7 {
+			t.Errorf("Expected explicit updated_at to be kept, got %v", a)
+		}
+	})
+}
+
+// assertGroupedFilter checks that conds is one AND group holding the caller's
+// conditions followed by a deleted_at filter using op.
+func assertGroupedFilter(t *testing.T, conds []orm.Condition, op orm.Operator) {
+	t.Helper()
+	if len(conds) != 2 || conds[0].Operator() != orm.OpGroup || len(conds[0].Children()) != 2 {
+		t.Fatalf("Expected grouped conditions plus filter, got %v", conds)
+	}
+	if f := conds[1]; f.Field() != "deleted_at" || f.Operator() != op || f.Logic() != "AND" {
+		t.Errorf("Expected AND deleted_at %s, got %s %s %s", op, f.Logic(), f.Field(), f.Operator())
+	}
+}
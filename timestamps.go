@@ -0,0 +1,76 @@
+package orm
+
+import "github.com/tinywasm/fmt"
+
+// CreatedAtStamper is an optional Model extension generated by ormc for a
+// db:"created_at" int64 field. Create, CreateMany and Upsert fill the column
+// from the DB clock (see SetClock) when it still holds zero; Update and the
+// update branch of Upsert leave it untouched.
+type CreatedAtStamper interface {
+	CreatedAtColumn() string
+}
+
+// UpdatedAtStamper is an optional Model extension generated by ormc for a
+// db:"updated_at" int64 field. Inserts fill it like created_at; Update,
+// UpdateColumns, Upsert, QB.UpdateAll, soft deletes and Restore always set
+// it to the DB clock.
+type UpdatedAtStamper interface {
+	UpdatedAtColumn() string
+}
+
+// createdAtColumn returns the created_at column of m, or "" when m has none.
+func createdAtColumn(m Model) string {
+	if s, ok := m.(CreatedAtStamper); ok {
+		return s.CreatedAtColumn()
+	}
+	return ""
+}
+
+// stampCreate fills the created_at and updated_at columns of m that are
+// still zero.
+func (db *DB) stampCreate(m Model) {
+	now := db.now()
+	if s, ok := m.(CreatedAtStamper); ok {
+		stampColumn(m, s.CreatedAtColumn(), now, false)
+	}
+	if s, ok := m.(UpdatedAtStamper); ok {
+		stampColumn(m, s.UpdatedAtColumn(), now, false)
+	}
+}
+
+// stampUpdate sets the updated_at column of m to the DB clock and returns
+// its name, or "" when m has none.
+func (db *DB) stampUpdate(m Model) string {
+	s, ok := m.(UpdatedAtStamper)
+	if !ok {
+		return ""
+	}
+	col := s.UpdatedAtColumn()
+	stampColumn(m, col, db.now(), true)
+	return col
+}
+
+// withUpdatedAt appends an assignment of now to the updated_at column of m,
+// if any, for updates that do not go through the model's values.
+func withUpdatedAt(m Model, assignments []Assignment, now int64) []Assignment {
+	if s, ok := m.(UpdatedAtStamper); ok {
+		return append(assignments, Assignment{column: s.UpdatedAtColumn(), kind: AssignNow, value: now})
+	}
+	return assignments
+}
+
+// stampColumn writes now through the pointer of column, unless the field
+// already holds a non-zero value and overwrite is false.
+func stampColumn(m Model, column string, now int64, overwrite bool) {
+	schema := m.Schema()
+	for i, f := range schema {
+		if f.Name != column {
+			continue
+		}
+		ptrs := m.Pointers()
+		if overwrite || isZeroInt(fmt.ReadValues(schema[i:i+1], ptrs[i:i+1])[0]) {
+			setInt(ptrs[i], now)
+		}
+		return
+	}
+}
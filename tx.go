@@ -20,17 +20,22 @@ func (db *DB) Tx(fn func(tx *DB) error) error {
 		return ErrNoTxSupport
 	}
 
-	bound, err := txExec.BeginTx()
+	var bound TxBoundExecutor
+	var err error
+	if ctxExec, ok := txExec.(TxContextExecutor); ok && db.ctx != nil {
+		bound, err = ctxExec.BeginTxContext(db.ctx)
+	} else if err = db.ctxErr(); err == nil {
+		bound, err = txExec.BeginTx()
+	}
 	if err != nil {
 		return err
 	}
 
-	txDB := &DB{
-		exec:     bound,
-		compiler: db.compiler,
-	}
+	txDB := *db
+	txDB.exec = bound
+	txDB.inTx = true
 
-	if err := fn(txDB); err != nil {
+	if err := fn(&txDB); err != nil {
 		bound.Rollback()
 		return err
 	}
@@ -7,7 +7,7 @@ func validate(action Action, m Model) error {
 		return ErrEmptyTable
 	}
 
-	if action == ActionCreate || action == ActionUpdate {
+	if action == ActionCreate || action == ActionUpdate || action == ActionUpsert {
 		if len(m.Schema()) != len(m.Pointers()) {
 			return fmt.Err(ErrValidation, "schema and pointers length mismatch")
 		}
@@ -15,3 +15,23 @@ func validate(action Action, m Model) error {
 
 	return nil
 }
+
+// validateColumns checks that every column exists in the model schema.
+func validateColumns(m Model, columns []string) error {
+	schema := m.Schema()
+	for _, c := range columns {
+		if !hasColumn(schema, c) {
+			return fmt.Err(ErrValidation, "unknown column", c)
+		}
+	}
+	return nil
+}
+
+func hasColumn(schema []fmt.Field, column string) bool {
+	for _, f := range schema {
+		if f.Name == column {
+			return true
+		}
+	}
+	return false
+}
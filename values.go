@@ -0,0 +1,121 @@
+package orm
+
+import "github.com/tinywasm/fmt"
+
+// isZeroInt reports whether v is an integer value equal to zero.
+// Values read by fmt.ReadValues for FieldInt columns are one of the
+// int/uint variants handled below.
+func isZeroInt(v any) bool {
+	switch n := v.(type) {
+	case int:
+		return n == 0
+	case int32:
+		return n == 0
+	case int64:
+		return n == 0
+	case uint:
+		return n == 0
+	case uint32:
+		return n == 0
+	case uint64:
+		return n == 0
+	}
+	return false
+}
+
+// setInt writes v through an integer pointer returned by Model.Pointers().
+// It reports false when ptr is not a supported integer pointer.
+func setInt(ptr any, v int64) bool {
+	switch p := ptr.(type) {
+	case *int:
+		*p = int(v)
+	case *int32:
+		*p = int32(v)
+	case *int64:
+		*p = v
+	case *uint:
+		*p = uint(v)
+	case *uint32:
+		*p = uint32(v)
+	case *uint64:
+		*p = uint64(v)
+	default:
+		return false
+	}
+	return true
+}
+
+// assignScanned writes a value scanned into an any (as database/sql yields
+// it: int64, float64, bool, []byte, string) through a Model pointer.
+// It reports false when the value cannot be stored in ptr.
+func assignScanned(ptr any, raw any) bool {
+	text, isText := "", false
+	switch v := raw.(type) {
+	case string:
+		text, isText = v, true
+	case []byte:
+		text, isText = string(v), true
+	}
+
+	switch p := ptr.(type) {
+	case *string:
+		if isText {
+			*p = text
+			return true
+		}
+	case *[]byte:
+		if isText {
+			*p = []byte(text)
+			return true
+		}
+	case *bool:
+		switch v := raw.(type) {
+		case bool:
+			*p = v
+			return true
+		case int64:
+			*p = v != 0
+			return true
+		}
+		if isText {
+			b, err := fmt.Convert(text).Bool()
+			*p = b
+			return err == nil
+		}
+	case *float64, *float32:
+		f, ok := 0.0, true
+		switch v := raw.(type) {
+		case float64:
+			f = v
+		case float32:
+			f = float64(v)
+		case int64:
+			f = float64(v)
+		default:
+			var err error
+			f, err = fmt.Convert(text).Float64()
+			ok = isText && err == nil
+		}
+		if !ok {
+			return false
+		}
+		if p32, is32 := p.(*float32); is32 {
+			*p32 = float32(f)
+		} else {
+			*p.(*float64) = f
+		}
+		return true
+	default:
+		switch v := raw.(type) {
+		case int64:
+			return setInt(ptr, v)
+		case int:
+			return setInt(ptr, int64(v))
+		}
+		if isText {
+			n, err := fmt.Convert(text).Int64()
+			return err == nil && setInt(ptr, n)
+		}
+	}
+	return false
+}